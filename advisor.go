@@ -0,0 +1,127 @@
+package nonlinear
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaxTableSize is the largest table TableSize will consider.
+const MaxTableSize = 1 << 20
+
+// checkPoints are the fractions of an interval at which interpolation errors are measured, offset
+// from simple fractions so as not to alias with features such as steps.
+var checkPoints = []float64{0.17, 0.33, 0.5, 0.67, 0.83}
+
+// tableError returns the largest difference between f and its linear interpolation from n evenly
+// spaced samples, measured at the checkPoints within each interval.
+func tableError(f NonLinear, n int) float64 {
+	m := 0.0
+	v0 := f.Transform(0)
+	for i := 1; i < n; i++ {
+		t0, t1 := float64(i-1)/float64(n-1), float64(i)/float64(n-1)
+		v1 := f.Transform(t1)
+		for _, x := range checkPoints {
+			d := math.Abs(f.Transform(t0+x*(t1-t0)) - ((1-x)*v0 + x*v1))
+			if !(d <= m) {
+				m = d
+			}
+		}
+		v0 = v1
+	}
+	return m
+}
+
+// TableSize returns the smallest number of evenly spaced samples for which linear interpolation, as
+// used by NLTable, is within maxErr of f, or 0 if more than MaxTableSize would be needed or maxErr
+// isn't > 0. Only the transform is considered.
+func TableSize(f NonLinear, maxErr float64) int {
+	if !(maxErr > 0) {
+		return 0
+	}
+	// Double until within budget, then bisect between the last two sizes
+	hi := 2
+	for tableError(f, hi) > maxErr {
+		if hi >= MaxTableSize {
+			return 0
+		}
+		hi = min(2*hi, MaxTableSize)
+	}
+	lo := hi / 2
+	if lo < 2 {
+		return hi
+	}
+	for hi-lo > 1 {
+		m := (lo + hi) / 2
+		if tableError(f, m) > maxErr {
+			lo = m
+		} else {
+			hi = m
+		}
+	}
+	return hi
+}
+
+// NewNLTableFor returns the smallest NLTable, as determined by TableSize, that's within maxErr of f.
+func NewNLTableFor(f NonLinear, maxErr float64) (*NLTable, error) {
+	if !(maxErr > 0) {
+		return nil, invalidParam("maxErr", maxErr, "> 0")
+	}
+	n := TableSize(f, maxErr)
+	if n == 0 {
+		return nil, fmt.Errorf("nonlinear: no table of up to %d entries is within %g", MaxTableSize, maxErr)
+	}
+	return NewNLTable(f, n), nil
+}
+
+// NewNLStoppedFor returns an NLStopped approximating f to within about maxErr, measured at several
+// points in each interval, with stops placed more densely where f bends sharply. Where f is
+// discontinuous the error can't be met and stops are placed down to intervals of 2^-20.
+func NewNLStoppedFor(f NonLinear, maxErr float64) *NLStopped {
+	return NewNLStopped(adaptivePoints(f, maxErr))
+}
+
+// adaptivePoints returns t, f(t) pairs, including t = 0 and t = 1, such that linear interpolation
+// between them is within maxErr of f, by recursively halving intervals that aren't.
+func adaptivePoints(f NonLinear, maxErr float64) [][]float64 {
+	const maxDepth = 20
+	v0, v1 := f.Transform(0), f.Transform(1)
+	pts := [][]float64{{0, v0}}
+	var sub func(t0, v0, t1, v1 float64, depth int)
+	sub = func(t0, v0, t1, v1 float64, depth int) {
+		tm := (t0 + t1) / 2
+		vm := f.Transform(tm)
+		split := false
+		for _, x := range checkPoints {
+			if depth >= maxDepth {
+				break
+			}
+			vx := vm
+			if x != 0.5 {
+				vx = f.Transform(t0 + x*(t1-t0))
+			}
+			if math.Abs(vx-((1-x)*v0+x*v1)) > maxErr {
+				split = true
+				break
+			}
+		}
+		if !split {
+			pts = append(pts, []float64{t1, v1})
+			return
+		}
+		sub(t0, v0, tm, vm, depth+1)
+		sub(tm, vm, t1, v1, depth+1)
+	}
+	// Start from a few intervals so that features smaller than the whole range aren't missed
+	const n = 8
+	pt, pv := 0.0, v0
+	for i := 1; i <= n; i++ {
+		t := float64(i) / n
+		v := v1
+		if i < n {
+			v = f.Transform(t)
+		}
+		sub(pt, pv, t, v, 0)
+		pt, pv = t, v
+	}
+	return pts
+}
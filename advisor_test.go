@@ -0,0 +1,20 @@
+package nonlinear
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTableSizeInvalid(t *testing.T) {
+	for _, eps := range []float64{math.NaN(), 0, -1} {
+		if n := TableSize(&NLP3{}, eps); n != 0 {
+			t.Errorf("TableSize(%g) = %d, want 0", eps, n)
+		}
+		if _, err := NewNLTableFor(&NLP3{}, eps); err == nil {
+			t.Errorf("NewNLTableFor(%g) succeeded, want an error", eps)
+		}
+	}
+	if n := TableSize(&NLLinear{}, 1e-9); n != 2 {
+		t.Errorf("TableSize(linear) = %d, want 2", n)
+	}
+}
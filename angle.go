@@ -0,0 +1,55 @@
+package nonlinear
+
+import "math"
+
+// AngleDirection selects the way round the circle NLerpAngle travels.
+type AngleDirection int
+
+const (
+	Shortest   AngleDirection = iota // The shorter arc, counter-clockwise for exactly half a turn
+	Increasing                       // Counter-clockwise, the angle always increases
+	Decreasing                       // Clockwise, the angle always decreases
+)
+
+// NLerpAngle interpolates between the angles start and end, in radians, using f at t (clamped to
+// [0,1]). The arc taken is at most one turn and determined by dir. The result is relative to start
+// and not reduced to [0,2Pi).
+func NLerpAngle(t, start, end float64, f NonLinear, dir AngleDirection) float64 {
+	return nlerpAngle(t, start, end, 2*math.Pi, f, dir)
+}
+
+// NLerpAngleDeg is NLerpAngle for angles in degrees.
+func NLerpAngleDeg(t, start, end float64, f NonLinear, dir AngleDirection) float64 {
+	return nlerpAngle(t, start, end, 360, f, dir)
+}
+
+func nlerpAngle(t, start, end, turn float64, f NonLinear, dir AngleDirection) float64 {
+	if t < 0 {
+		t = 0
+	} else if t > 1 {
+		t = 1
+	}
+	return start + angleDelta(start, end, turn, dir)*f.Transform(t)
+}
+
+// angleDelta returns the signed angle from a to b in the direction dir, where turn is a full circle.
+func angleDelta(a, b, turn float64, dir AngleDirection) float64 {
+	d := math.Mod(b-a, turn)
+	if d < 0 {
+		d += turn
+	}
+	// d is now in [0,turn)
+	switch dir {
+	case Increasing:
+		return d
+	case Decreasing:
+		if d == 0 {
+			return 0
+		}
+		return d - turn
+	}
+	if d > turn/2 {
+		return d - turn
+	}
+	return d
+}
@@ -0,0 +1,85 @@
+package nonlinear
+
+import "time"
+
+// Animator updates a set of tweens together, passing each new value to the tween's setter, e.g. one
+// setting an element's opacity, and dropping tweens once done. Setters may add and remove tweens;
+// those added take effect from the next Update. The zero value is an empty Animator.
+type Animator struct {
+	entries  []animatorEntry
+	updating bool
+}
+
+type animatorEntry struct {
+	tw  *Tween
+	set func(float64)
+}
+
+// Add starts animating tw, calling set with its value after each update. If tw is already being
+// animated its setter is replaced.
+func (a *Animator) Add(tw *Tween, set func(float64)) {
+	for i, e := range a.entries {
+		if e.tw == tw {
+			a.entries[i].set = set
+			return
+		}
+	}
+	a.entries = append(a.entries, animatorEntry{tw, set})
+}
+
+// Remove stops animating tw, without calling its setter again. Returns false if tw wasn't being
+// animated.
+func (a *Animator) Remove(tw *Tween) bool {
+	for i, e := range a.entries {
+		if e.tw == tw {
+			a.entries[i] = animatorEntry{}
+			if !a.updating {
+				a.compact()
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of tweens being animated.
+func (a *Animator) Len() int {
+	n := 0
+	for _, e := range a.entries {
+		if e.tw != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Update advances every tween by dt and calls its setter, then drops those that are done.
+func (a *Animator) Update(dt time.Duration) {
+	a.updating = true
+	for i, n := 0, len(a.entries); i < n; i++ {
+		e := a.entries[i]
+		if e.tw == nil {
+			continue
+		}
+		e.tw.Update(dt)
+		e.set(e.tw.Value())
+		if e.tw.Done() && a.entries[i].tw == e.tw {
+			a.entries[i] = animatorEntry{}
+		}
+	}
+	a.updating = false
+	a.compact()
+}
+
+// compact drops the removed entries.
+func (a *Animator) compact() {
+	j := 0
+	for _, e := range a.entries {
+		if e.tw != nil {
+			a.entries[j] = e
+			j++
+		}
+	}
+	clear(a.entries[j:])
+	a.entries = a.entries[:j]
+}
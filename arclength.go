@@ -0,0 +1,101 @@
+package nonlinear
+
+import (
+	"math"
+	"sync"
+)
+
+// ArcLength returns the length of the graph of f between 0 and t.
+func ArcLength(f NonLinear, t float64) float64 {
+	return arcLength(f, 0, t)
+}
+
+// ArcLengthInverse returns the t at which the length of the graph of f from 0 is s. s is clamped
+// to [0, ArcLength(f, 1)].
+func ArcLengthInverse(f NonLinear, s float64) float64 {
+	if s <= 0 {
+		return 0
+	}
+	l := arcLength(f, 0, 1)
+	if s >= l {
+		return 1
+	}
+
+	// Safeguarded Newton, falling back to bisection when a step leaves the bracket
+	lo, hi := 0.0, 1.0
+	t := s / l
+	for n := 0; n < 50; n++ {
+		g := arcLength(f, 0, t) - s
+		if math.Abs(g) < 1e-12 {
+			break
+		}
+		if g > 0 {
+			hi = t
+		} else {
+			lo = t
+		}
+		d := Derivative(f, t)
+		nt := t - g/math.Sqrt(1+d*d)
+		if !(nt > lo && nt < hi) {
+			nt = (lo + hi) / 2
+		}
+		t = nt
+	}
+	return t
+}
+
+// Gauss-Legendre nodes and weights on [-1,1], computed once.
+const glOrder = 20
+
+var (
+	glOnce sync.Once
+	glX    [glOrder]float64
+	glW    [glOrder]float64
+)
+
+func glInit() {
+	n := glOrder
+	for i := 0; i < n; i++ {
+		// Initial guess for the ith root of P_n and Newton refinement
+		x := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		var dp float64
+		for k := 0; k < 100; k++ {
+			p0, p1 := 1.0, x
+			for j := 2; j <= n; j++ {
+				p0, p1 = p1, ((2*float64(j)-1)*x*p1-(float64(j)-1)*p0)/float64(j)
+			}
+			dp = float64(n) * (x*p1 - p0) / (x*x - 1)
+			dx := p1 / dp
+			x -= dx
+			if math.Abs(dx) < 1e-16 {
+				break
+			}
+		}
+		glX[i] = x
+		glW[i] = 2 / ((1 - x*x) * dp * dp)
+	}
+}
+
+// arcLength integrates sqrt(1 + f'^2) over [a,b] using composite Gauss-Legendre quadrature.
+// The substitution t = a + (b-a)(3u^2 - 2u^3) removes the integrable singularities at the ends of
+// curves such as NLCircle1 and NLCircle2 whose slope is infinite there.
+func arcLength(f NonLinear, a, b float64) float64 {
+	glOnce.Do(glInit)
+	if b <= a {
+		return 0
+	}
+	w := b - a
+	panels := int(math.Ceil(w * 8))
+	h := 1 / float64(panels)
+	sum := 0.0
+	for p := 0; p < panels; p++ {
+		mid := (float64(p) + 0.5) * h
+		for i := 0; i < glOrder; i++ {
+			u := mid + glX[i]*h/2
+			dt := 6 * u * (1 - u) * w
+			d := Derivative(f, a+u*u*(3-2*u)*w)
+			sum += glW[i] * math.Sqrt(dt*dt+(d*dt)*(d*dt))
+		}
+	}
+	return sum * h / 2
+}
@@ -0,0 +1,42 @@
+package nonlinear
+
+// BarycentricWeights eases the barycentric weights w with f and renormalizes them to sum to 1. The
+// weights are clamped to [0,1] before easing. If all the eased weights are 0, the originals are
+// returned.
+func BarycentricWeights(w [3]float64, f NonLinear) [3]float64 {
+	var e [3]float64
+	sum := 0.0
+	for i, x := range w {
+		e[i] = NLerp(x, 0, 1, f)
+		sum += e[i]
+	}
+	if sum == 0 {
+		return w
+	}
+	for i := range e {
+		e[i] /= sum
+	}
+	return e
+}
+
+// Barycentric blends the three values with the weights w eased by BarycentricWeights.
+func Barycentric(w [3]float64, values [3]float64, f NonLinear) float64 {
+	e := BarycentricWeights(w, f)
+	return e[0]*values[0] + e[1]*values[1] + e[2]*values[2]
+}
+
+// BarycentricVec blends the vectors a, b and c, such as colors or positions, component by component
+// with the weights w eased by BarycentricWeights. If dst is nil or too short, a new slice is allocated.
+// Returns dst.
+func BarycentricVec(w [3]float64, a, b, c []float64, f NonLinear, dst []float64) []float64 {
+	e := BarycentricWeights(w, f)
+	n := len(a)
+	if len(dst) < n {
+		dst = make([]float64, n)
+	}
+	dst = dst[:n]
+	for i := range dst {
+		dst[i] = e[0]*a[i] + e[1]*b[i] + e[2]*c[i]
+	}
+	return dst
+}
@@ -0,0 +1,153 @@
+package nonlinear
+
+import "math"
+
+// NonLinearBatch is implemented by functions that can transform many values in one call, avoiding an
+// interface call per value. TransformBatch sets dst[i] = Transform(ts[i]) for i < len(ts).
+type NonLinearBatch interface {
+	NonLinear
+	TransformBatch(ts, dst []float64)
+}
+
+// TransformSliceAt sets dst[i] = f(ts[i]) for each of the ts. dst must be at least as long as ts.
+func TransformSliceAt(f NonLinear, ts, dst []float64) {
+	dst = dst[:len(ts)]
+	if fb, ok := f.(NonLinearBatch); ok {
+		fb.TransformBatch(ts, dst)
+		return
+	}
+	for i, t := range ts {
+		dst[i] = f.Transform(t)
+	}
+}
+
+// TransformSlice fills dst with f evaluated at len(dst) evenly spaced t from 0 to 1 inclusive.
+func TransformSlice(f NonLinear, dst []float64) {
+	evenSpaced(dst)
+	TransformSliceAt(f, dst, dst)
+}
+
+// NLerpSlice fills dst with NLerp(t, start, end, f) for len(dst) evenly spaced t from 0 to 1 inclusive.
+func NLerpSlice(start, end float64, f NonLinear, dst []float64) {
+	TransformSlice(f, dst)
+	for i, t := range dst {
+		dst[i] = (1-t)*start + t*end
+	}
+}
+
+// evenSpaced fills dst with evenly spaced values from 0 to 1 inclusive.
+func evenSpaced(dst []float64) {
+	n := len(dst) - 1
+	for i := range dst {
+		dst[i] = float64(i) / float64(n)
+	}
+	if n == 0 {
+		dst[0] = 0
+	}
+}
+
+func (nl *NLLinear) TransformBatch(ts, dst []float64) {
+	copy(dst, ts)
+}
+
+func (nl *NLSquare) TransformBatch(ts, dst []float64) {
+	squareSlice(ts, dst)
+}
+
+func (nl *NLCube) TransformBatch(ts, dst []float64) {
+	cubeSlice(ts, dst)
+}
+
+func (nl *NLP3) TransformBatch(ts, dst []float64) {
+	p3Slice(ts, dst)
+}
+
+func (nl *NLP5) TransformBatch(ts, dst []float64) {
+	p5Slice(ts, dst)
+}
+
+func (nl *NLPower) TransformBatch(ts, dst []float64) {
+	switch {
+	case nl.Fast:
+		dst = dst[:len(ts)]
+		for i, t := range ts {
+			dst[i] = fastPow(t, nl.P)
+		}
+	case nl.P == 1:
+		copy(dst, ts)
+	case nl.P == 2:
+		squareSlice(ts, dst)
+	case nl.P == 3:
+		cubeSlice(ts, dst)
+	default:
+		dst = dst[:len(ts)]
+		for i, t := range ts {
+			dst[i] = math.Pow(t, nl.P)
+		}
+	}
+}
+
+// The exponential curves are only batched when Fast, fastExp having a vector form.
+
+func (nl *NLExponential) TransformBatch(ts, dst []float64) {
+	dst = dst[:len(ts)]
+	if !nl.Fast {
+		for i, t := range ts {
+			dst[i] = nl.Transform(t)
+		}
+		return
+	}
+	for i, t := range ts {
+		dst[i] = t * nl.K
+	}
+	fastExpSlice(dst, dst)
+	for i, e := range dst {
+		dst[i] = (e - 1) * nl.Scale
+	}
+}
+
+func (nl *NLGauss) TransformBatch(ts, dst []float64) {
+	dst = dst[:len(ts)]
+	if !nl.Fast {
+		for i, t := range ts {
+			dst[i] = nl.Transform(t)
+		}
+		return
+	}
+	for i, t := range ts {
+		x := nl.K * (t - 1)
+		dst[i] = -0.5 * x * x
+	}
+	fastExpSlice(dst, dst)
+	for i, e := range dst {
+		dst[i] = (e - nl.Offs) * nl.Scale
+	}
+}
+
+func (nl *NLLogistic) TransformBatch(ts, dst []float64) {
+	dst = dst[:len(ts)]
+	if !nl.Fast {
+		for i, t := range ts {
+			dst[i] = nl.Transform(t)
+		}
+		return
+	}
+	for i, t := range ts {
+		dst[i] = -((t - nl.Mp) * nl.K)
+	}
+	fastExpSlice(dst, dst)
+	for i, e := range dst {
+		dst[i] = (1/(1+e) - nl.Offs) * nl.Scale
+	}
+}
+
+func (nl *NLCompound) TransformBatch(ts, dst []float64) {
+	if len(nl.Fs) == 0 {
+		copy(dst, ts)
+		return
+	}
+	TransformSliceAt(nl.Fs[0], ts, dst)
+	for _, f := range nl.Fs[1:] {
+		TransformSliceAt(f, dst[:len(ts)], dst)
+	}
+}
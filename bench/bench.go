@@ -0,0 +1,180 @@
+// Package bench measures the cost and inverse accuracy of curves from package nonlinear, so that for
+// example NLLogistic can be compared with a baked NLTable of it. Results can be written as JSON or
+// CSV.
+package bench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/jphsd/nonlinear"
+)
+
+// Options control how long each curve is timed for and how finely its inverse is checked.
+type Options struct {
+	Duration time.Duration // Minimum time spent timing each of Transform and InvTransform
+	Samples  int           // Number of intervals in [0,1] at which the round trip is checked
+}
+
+// DefaultOptions are used by Registered when passed a zero Options.
+var DefaultOptions = Options{100 * time.Millisecond, 1000}
+
+// Result records the measurements for one curve. MaxRoundTrip is the largest
+// |InvTransform(Transform(t)) - t| over the samples, and is NaN if the round trip wasn't finite
+// somewhere.
+type Result struct {
+	Name           string  // Registry name
+	Curve          string  // The curve measured, as formatted by nonlinear.FormatCurve
+	TransformNs    float64 // Nanoseconds per call
+	InvTransformNs float64 // Nanoseconds per call
+	MaxRoundTrip   float64
+	MaxRoundTripT  float64 // t at which MaxRoundTrip occurs
+	Err            string  // Set if the curve couldn't be constructed, in which case nothing was measured
+}
+
+// Examples holds the expressions, in the form accepted by nonlinear.Parse, used to construct the
+// registered curves that need parameters or wrap other curves. Curves not listed are constructed with
+// no parameters. Register your own curves here to include them in Registered.
+var Examples = map[string]string{
+	"back":             "back(1.70158)",
+	"cached":           "cached(logistic(12,0.5),256)",
+	"compound":         "compound(p3,square)",
+	"conditional":      "conditional(square,p3,0.5)",
+	"constantspeed":    "constantspeed(p3,64)",
+	"cubicbezier":      "cubicbezier(0.42,0,0.58,1)",
+	"descend":          "descend(p3)",
+	"elastic":          "elastic(0.3)",
+	"domain":           "domain(circle1,0)",
+	"exact":            "exact(gauss(2))",
+	"exponential":      "exponential(3)",
+	"exponential_fast": "exponential_fast(3)",
+	"gauss":            "gauss(2)",
+	"gauss_fast":       "gauss_fast(2)",
+	"inout":            "inout(square,omt(square),0.5)",
+	"inverse":          "inverse(p3,1e-6,32)",
+	"invtable":         "invtable(p3,1024)",
+	"lame":             "lame(2,2)",
+	"logarithmic":      "logarithmic(3)",
+	"logistic":         "logistic(12,0.5)",
+	"logistic_fast":    "logistic_fast(12,0.5)",
+	"normalized":       "normalized(sin)",
+	"omt":              "omt(square)",
+	"polynomial":       "polynomial(0,0,3,-2)",
+	"power":            "power(2.5)",
+	"power_fast":       "power_fast(2.5)",
+	"softclamp":        "softclamp(linear,0.1)",
+	"spline":           "spline(0.25:0.5,0.5:0.6)",
+	"spring":           "spring(170,26,1,1)",
+	"steps":            "steps(4,0)",
+	"stops":            "stops(0.25:0.5)",
+	"table":            "table(logistic(12,0.5),256)",
+	"window":           "window(p3,0.25,0.75)",
+}
+
+// Registered measures every curve in the registry, in name order.
+func Registered(opts Options) []Result {
+	if opts == (Options{}) {
+		opts = DefaultOptions
+	}
+	var res []Result
+	for _, info := range nonlinear.ListCurves() {
+		var f nonlinear.NonLinear
+		var err error
+		if ex, ok := Examples[info.Name]; ok {
+			f, err = nonlinear.Parse(ex)
+		} else {
+			f, err = nonlinear.New(info.Name)
+		}
+		if err != nil {
+			res = append(res, Result{Name: info.Name, Err: err.Error()})
+			continue
+		}
+		res = append(res, Curve(info.Name, f, opts))
+	}
+	return res
+}
+
+// sink keeps the timed calls from being optimized away.
+var sink float64
+
+// Curve measures f, recording it under name.
+func Curve(name string, f nonlinear.NonLinear, opts Options) Result {
+	if opts.Samples < 1 {
+		opts.Samples = 1
+	}
+	r := Result{Name: name, Curve: nonlinear.FormatCurve(f)}
+	r.TransformNs = timeCalls(f.Transform, opts.Duration)
+	r.InvTransformNs = timeCalls(f.InvTransform, opts.Duration)
+	r.MaxRoundTrip, _, r.MaxRoundTripT = nonlinear.RoundTripError(f, opts.Samples)
+	if math.IsInf(r.MaxRoundTrip, 0) {
+		r.MaxRoundTrip = math.NaN()
+	}
+	return r
+}
+
+// timeCalls returns the mean time in nanoseconds of fn over [0,1], calling it in rounds of 1024
+// evenly spaced values until at least d has passed.
+func timeCalls(fn func(float64) float64, d time.Duration) float64 {
+	const n = 1024
+	var xs [n]float64
+	for i := range xs {
+		xs[i] = float64(i) / (n - 1)
+	}
+	calls := 0
+	s := 0.0
+	start := time.Now()
+	for {
+		for _, x := range xs {
+			s += fn(x)
+		}
+		calls += n
+		if el := time.Since(start); el >= d {
+			sink = s
+			return float64(el.Nanoseconds()) / float64(calls)
+		}
+	}
+}
+
+// WriteJSON writes rs as a JSON array of objects. A MaxRoundTrip of NaN is written as null.
+func WriteJSON(w io.Writer, rs []Result) error {
+	type result struct {
+		Name           string   `json:"name"`
+		Curve          string   `json:"curve,omitempty"`
+		TransformNs    float64  `json:"transform_ns"`
+		InvTransformNs float64  `json:"inv_transform_ns"`
+		MaxRoundTrip   *float64 `json:"max_round_trip"`
+		MaxRoundTripT  float64  `json:"max_round_trip_t"`
+		Err            string   `json:"error,omitempty"`
+	}
+	out := make([]result, len(rs))
+	for i, r := range rs {
+		out[i] = result{r.Name, r.Curve, r.TransformNs, r.InvTransformNs, nil, r.MaxRoundTripT, r.Err}
+		if !math.IsNaN(r.MaxRoundTrip) {
+			out[i].MaxRoundTrip = &rs[i].MaxRoundTrip
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// WriteCSV writes rs as CSV with a header row.
+func WriteCSV(w io.Writer, rs []Result) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"name", "curve", "transform_ns", "inv_transform_ns", "max_round_trip", "max_round_trip_t", "error"}); err != nil {
+		return err
+	}
+	ff := func(v float64) string { return strconv.FormatFloat(v, 'g', 6, 64) }
+	for _, r := range rs {
+		row := []string{r.Name, r.Curve, ff(r.TransformNs), ff(r.InvTransformNs), ff(r.MaxRoundTrip), ff(r.MaxRoundTripT), r.Err}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
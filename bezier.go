@@ -0,0 +1,91 @@
+package nonlinear
+
+import "math"
+
+// NLCubicBezier v = y(u) where t = x(u), for the cubic Bezier curve with control points (0,0),
+// (x1,y1), (x2,y2) and (1,1), as used by CSS cubic-bezier(). x1 and x2 must be in [0,1] so that
+// x(u) is monotonic. The inverse assumes y1 and y2 are also in [0,1].
+type NLCubicBezier struct {
+	X1, Y1, X2, Y2 float64
+}
+
+func NewNLCubicBezier(x1, y1, x2, y2 float64) *NLCubicBezier {
+	return &NLCubicBezier{x1, y1, x2, y2}
+}
+
+// bezierCoeffs returns the polynomial coefficients a, b, c of a u^3 + b u^2 + c u for the
+// control values p1 and p2.
+func bezierCoeffs(p1, p2 float64) (float64, float64, float64) {
+	c := 3 * p1
+	b := 3*(p2-p1) - c
+	return 1 - c - b, b, c
+}
+
+func bezierEval(a, b, c, u float64) float64 {
+	return ((a*u+b)*u + c) * u
+}
+
+func bezierDeriv(a, b, c, u float64) float64 {
+	return (3*a*u+2*b)*u + c
+}
+
+// bezierSolve finds u in [0,1] such that a u^3 + b u^2 + c u = x, using Newton's method with a
+// bisection fallback.
+func bezierSolve(a, b, c, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	lo, hi := 0.0, 1.0
+	u := x
+	for n := 0; n < 64; n++ {
+		fx := bezierEval(a, b, c, u) - x
+		if fx == 0 {
+			return u
+		}
+		if fx > 0 {
+			hi = u
+		} else {
+			lo = u
+		}
+		nu := u - fx/bezierDeriv(a, b, c, u)
+		if !(nu > lo && nu < hi) {
+			nu = (lo + hi) / 2
+		}
+		if math.Abs(nu-u) < 1e-15 {
+			return nu
+		}
+		u = nu
+	}
+	return u
+}
+
+func (nl *NLCubicBezier) Transform(t float64) float64 {
+	ax, bx, cx := bezierCoeffs(nl.X1, nl.X2)
+	ay, by, cy := bezierCoeffs(nl.Y1, nl.Y2)
+	return bezierEval(ay, by, cy, bezierSolve(ax, bx, cx, t))
+}
+
+func (nl *NLCubicBezier) InvTransform(v float64) float64 {
+	ax, bx, cx := bezierCoeffs(nl.X1, nl.X2)
+	ay, by, cy := bezierCoeffs(nl.Y1, nl.Y2)
+	return bezierEval(ax, bx, cx, bezierSolve(ay, by, cy, v))
+}
+
+func (nl *NLCubicBezier) Derivative(t float64) float64 {
+	ax, bx, cx := bezierCoeffs(nl.X1, nl.X2)
+	ay, by, cy := bezierCoeffs(nl.Y1, nl.Y2)
+	u := bezierSolve(ax, bx, cx, t)
+	return bezierDeriv(ay, by, cy, u) / bezierDeriv(ax, bx, cx, u)
+}
+
+func (nl *NLCubicBezier) SecondDerivative(t float64) float64 {
+	ax, bx, cx := bezierCoeffs(nl.X1, nl.X2)
+	ay, by, cy := bezierCoeffs(nl.Y1, nl.Y2)
+	u := bezierSolve(ax, bx, cx, t)
+	dx, dy := bezierDeriv(ax, bx, cx, u), bezierDeriv(ay, by, cy, u)
+	ddx, ddy := 6*ax*u+2*bx, 6*ay*u+2*by
+	return (ddy*dx - dy*ddx) / (dx * dx * dx)
+}
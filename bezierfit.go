@@ -0,0 +1,68 @@
+package nonlinear
+
+import "math"
+
+// ToCubicBezier returns the control points of the CSS style cubic Bezier (see NLCubicBezier) that
+// best fits f in the least squares sense, along with the maximum absolute difference between the two.
+// x1 and x2 are constrained to [0,1].
+func ToCubicBezier(f NonLinear) (x1, y1, x2, y2, maxErr float64) {
+	const n = 64
+	ts := make([]float64, n)
+	vs := make([]float64, n)
+	for i := range ts {
+		ts[i] = (float64(i) + 0.5) / n
+		vs[i] = f.Transform(ts[i])
+	}
+
+	clamp := func(p []float64) *NLCubicBezier {
+		return NewNLCubicBezier(math.Min(1, math.Max(0, p[0])), p[1], math.Min(1, math.Max(0, p[2])), p[3])
+	}
+	cost := func(p []float64) float64 {
+		b := clamp(p)
+		sum := 0.0
+		for i, t := range ts {
+			d := b.Transform(t) - vs[i]
+			sum += d * d
+		}
+		// Penalize leaving the x domain so the simplex is pushed back into it
+		for _, x := range []float64{p[0], p[2]} {
+			if x < 0 {
+				sum += x * x
+			} else if x > 1 {
+				sum += (x - 1) * (x - 1)
+			}
+		}
+		return sum
+	}
+
+	// Start from the end slopes, and from a couple of common shapes
+	slope := func(d float64) float64 {
+		if math.IsNaN(d) || math.IsInf(d, 0) {
+			return 10
+		}
+		return math.Max(-10, math.Min(10, d))
+	}
+	d0, d1 := slope(Derivative(f, 0)), slope(Derivative(f, 1))
+	starts := [][]float64{
+		{1.0 / 3, d0 / 3, 2.0 / 3, 1 - d1/3},
+		{0.42, 0, 0.58, 1},
+		{0.25, 0.25, 0.75, 0.75},
+	}
+	var best []float64
+	bestCost := math.Inf(1)
+	for _, s := range starts {
+		p, c := nelderMead(cost, s, 0.1, 2000)
+		if c < bestCost {
+			best, bestCost = p, c
+		}
+	}
+
+	b := clamp(best)
+	for i := 0; i <= 1000; i++ {
+		t := float64(i) / 1000
+		if d := math.Abs(b.Transform(t) - f.Transform(t)); d > maxErr {
+			maxErr = d
+		}
+	}
+	return b.X1, b.Y1, b.X2, b.Y2, maxErr
+}
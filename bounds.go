@@ -0,0 +1,147 @@
+package nonlinear
+
+import "math"
+
+const (
+	boundsIntervals = 16   // Intervals Bounds starts with
+	boundsDepth     = 6    // Times an interval may be halved, so features down to 1/1024th are found
+	boundsTol       = 1e-3 // Chord error, relative to the bounds so far, above which f is resampled
+)
+
+// Bounds returns the smallest and largest values of f for t in [t0,t1]. If f implements NonLinearD
+// the extrema are located at the roots of its derivative, bracketed by adaptively sampling only the
+// derivative, so f itself is only evaluated at t0, t1 and the roots. Otherwise f is sampled
+// adaptively, more densely where it curves, and its local extrema refined with a golden section
+// search. Either way, features narrower than 1/1024th of the range may be missed.
+func Bounds(f NonLinear, t0, t1 float64) (min, max float64) {
+	if t0 > t1 {
+		t0, t1 = t1, t0
+	}
+	min, max = math.Inf(1), math.Inf(-1)
+	add := func(v float64) {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	add(f.Transform(t0))
+	add(f.Transform(t1))
+	if t0 == t1 {
+		return
+	}
+	h := (t1 - t0) / boundsIntervals
+
+	if fd, ok := f.(NonLinearD); ok {
+		d := fd.Derivative
+		var scan func(a, b, da, db float64, depth int)
+		scan = func(a, b, da, db float64, depth int) {
+			if da*db < 0 {
+				add(f.Transform(signChange(d, a, b, da)))
+				return
+			}
+			if depth == 0 {
+				return
+			}
+			// Halve where the derivative may dip to a pair of roots
+			m := (a + b) / 2
+			dm := d(m)
+			if dm == 0 {
+				add(f.Transform(m))
+			}
+			if dm*da < 0 || math.Abs(dm) < math.Min(math.Abs(da), math.Abs(db)) {
+				scan(a, m, da, dm, depth-1)
+				scan(m, b, dm, db, depth-1)
+			}
+		}
+		a, da := t0, d(t0)
+		for i := 1; i <= boundsIntervals; i++ {
+			b := t0 + h*float64(i)
+			if i == boundsIntervals {
+				b = t1
+			}
+			db := d(b)
+			if db == 0 && i < boundsIntervals {
+				add(f.Transform(b))
+			}
+			scan(a, b, da, db, boundsDepth)
+			a, da = b, db
+		}
+		return
+	}
+
+	neg := func(t float64) float64 { return -f.Transform(t) }
+	var scan func(a, b, fa, fb float64, depth int)
+	scan = func(a, b, fa, fb float64, depth int) {
+		m := (a + b) / 2
+		fm := f.Transform(m)
+		add(fm)
+		switch {
+		case fm > fa && fm > fb:
+			add(f.Transform(goldenMin(neg, a, b)))
+		case fm < fa && fm < fb:
+			add(f.Transform(goldenMin(f.Transform, a, b)))
+		case depth > 0 && math.Abs(fm-(fa+fb)/2) > boundsTol*(max-min):
+			scan(a, m, fa, fm, depth-1)
+			scan(m, b, fm, fb, depth-1)
+		}
+	}
+	a, fa := t0, f.Transform(t0)
+	for i := 1; i <= boundsIntervals; i++ {
+		b := t0 + h*float64(i)
+		if i == boundsIntervals {
+			b = t1
+		}
+		fb := f.Transform(b)
+		add(fb)
+		scan(a, b, fa, fb, boundsDepth)
+		a, fa = b, fb
+	}
+	return
+}
+
+// signChange bisects [a,b] for the point where fn changes sign, given fa, the sign of fn at a.
+func signChange(fn func(float64) float64, a, b, fa float64) float64 {
+	for i := 0; i < 64; i++ {
+		m := (a + b) / 2
+		if m <= a || m >= b {
+			break
+		}
+		fm := fn(m)
+		if fm == 0 {
+			return m
+		}
+		if (fm < 0) == (fa < 0) {
+			a = m
+		} else {
+			b = m
+		}
+	}
+	return (a + b) / 2
+}
+
+// goldenMin returns the t in [a,b] minimizing fn, assumed unimodal there, by golden section search.
+func goldenMin(fn func(float64) float64, a, b float64) float64 {
+	const r = 0.6180339887498949 // 1/phi
+	c, d := b-r*(b-a), a+r*(b-a)
+	fc, fd := fn(c), fn(d)
+	for i := 0; i < 80; i++ {
+		if b-a <= 1e-12*(1+math.Abs(a)) {
+			break
+		}
+		if fc < fd {
+			b, d, fd = d, c, fc
+			c = b - r*(b-a)
+			fc = fn(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + r*(b-a)
+			fd = fn(d)
+		}
+	}
+	if fc < fd {
+		return c
+	}
+	return d
+}
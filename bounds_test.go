@@ -0,0 +1,75 @@
+package nonlinear
+
+import (
+	"math"
+	"testing"
+)
+
+// countingCurve counts the calls to Transform of the curve it wraps, hiding any derivatives.
+type countingCurve struct {
+	f NonLinear
+	n int
+}
+
+func (c *countingCurve) Transform(t float64) float64 {
+	c.n++
+	return c.f.Transform(t)
+}
+
+func (c *countingCurve) InvTransform(v float64) float64 {
+	return c.f.InvTransform(v)
+}
+
+// countingCurveD is countingCurve passing on the derivative.
+type countingCurveD struct {
+	countingCurve
+}
+
+func (c *countingCurveD) Derivative(t float64) float64 {
+	return Derivative(c.f, t)
+}
+
+func TestBounds(t *testing.T) {
+	c := 1.70158
+	tb := 2 * c / (3 * (c + 1)) // Where NLBack is lowest
+	back := NewNLBack(c)
+	tests := []struct {
+		name     string
+		f        NonLinear
+		t0, t1   float64
+		min, max float64
+	}{
+		{"p3", &NLP3{}, 0, 1, 0, 1},
+		{"p3 part", &NLP3{}, 0.25, 0.5, (&NLP3{}).Transform(0.25), 0.5},
+		{"back", back, 0, 1, back.Transform(tb), 1},
+		{"sin wave", NewNLFunc(func(t float64) float64 { return t + math.Sin(4*math.Pi*t)/8 }), 0, 1,
+			math.Inf(1), math.Inf(-1)},
+	}
+	for _, tt := range tests {
+		for _, deriv := range []bool{true, false} {
+			cc := &countingCurveD{countingCurve{f: tt.f}}
+			var f NonLinear = &cc.countingCurve
+			if deriv {
+				f = cc
+			}
+			min, max := Bounds(f, tt.t0, tt.t1)
+			if math.IsInf(tt.min, 1) {
+				// Compare with dense sampling
+				tt.min, tt.max = math.Inf(1), math.Inf(-1)
+				for i := 0; i <= 100000; i++ {
+					v := tt.f.Transform(tt.t0 + (tt.t1-tt.t0)*float64(i)/100000)
+					tt.min, tt.max = math.Min(tt.min, v), math.Max(tt.max, v)
+				}
+			}
+			if math.Abs(min-tt.min) > 1e-8 || math.Abs(max-tt.max) > 1e-8 {
+				t.Errorf("%s (derivative %v): Bounds = %g, %g, want %g, %g", tt.name, deriv, min, max, tt.min, tt.max)
+			}
+			if deriv && cc.n > 8 {
+				t.Errorf("%s: Bounds evaluated f %d times", tt.name, cc.n)
+			}
+			if !deriv && cc.n > 400 {
+				t.Errorf("%s (no derivative): Bounds evaluated f %d times", tt.name, cc.n)
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package nonlinear
+
+import "math"
+
+// NonLinearAll is implemented by curves that aren't monotonic, and so may reach a value more than
+// once, and that can locate their monotonic branches exactly. InvTransformAll returns, in ascending
+// order, every t in [0,1] for which f(t) = v.
+type NonLinearAll interface {
+	NonLinear
+	InvTransformAll(v float64) []float64
+}
+
+// inverseAllSamples is the number of intervals InvTransformAll samples f over, for curves that don't
+// implement NonLinearAll.
+const inverseAllSamples = 1024
+
+// InvTransformAll returns, in ascending order, every t in [0,1] for which f(t) = v, where
+// InvTransform only returns one of them. If f doesn't implement NonLinearAll, f is sampled at 1024
+// intervals and crossings of v within each are refined by bisection, so pairs of solutions less than an
+// interval apart, as where f only just reaches v, may be missed.
+func InvTransformAll(f NonLinear, v float64) []float64 {
+	if fa, ok := f.(NonLinearAll); ok {
+		return fa.InvTransformAll(v)
+	}
+	breaks := make([]float64, inverseAllSamples+1)
+	evenSpaced(breaks)
+	return branchRoots(f, v, breaks)
+}
+
+// branchRoots returns the t for which f(t) = v, given ascending breaks from 0 to 1 between which f is
+// monotonic. A solution at a break is found once.
+func branchRoots(f NonLinear, v float64, breaks []float64) []float64 {
+	var res []float64
+	lo := breaks[0]
+	a := f.Transform(lo) - v
+	for i, hi := range breaks[1:] {
+		b := f.Transform(hi) - v
+		switch {
+		case a == 0:
+			res = append(res, lo)
+		case b == 0:
+			if i == len(breaks)-2 {
+				res = append(res, hi)
+			}
+		case (a < 0) != (b < 0):
+			res = append(res, bisectBranch(f, v, lo, hi, a < 0))
+		}
+		lo, a = hi, b
+	}
+	return res
+}
+
+// bisectBranch finds t in [lo,hi] with f(t) = v, where f is increasing over the interval
+// if rising, and decreasing otherwise.
+func bisectBranch(f NonLinear, v, lo, hi float64, rising bool) float64 {
+	opts := DefaultInverseOptions
+	for n := 0; n < opts.MaxIterations && hi-lo > opts.Tolerance; n++ {
+		t := (lo + hi) / 2
+		if (f.Transform(t) > v) == rising {
+			hi = t
+		} else {
+			lo = t
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// InvTransformAll splits f at its minimum, at t = 2c / 3(c+1).
+func (nl *NLBack) InvTransformAll(v float64) []float64 {
+	breaks := []float64{0, 1}
+	if tm := 2 * nl.C / (3 * (nl.C + 1)); tm > 0 && tm < 1 {
+		breaks = []float64{0, tm, 1}
+	}
+	return branchRoots(nl, v, breaks)
+}
+
+// InvTransformAll splits f at its turning points, where tan((t-1-p/4) * 2Pi/p) = -2Pi / (10 ln2 p).
+// t = 0 is handled separately since f(0) is defined rather than given by the formula.
+func (nl *NLElastic) InvTransformAll(v float64) []float64 {
+	w := 2 * math.Pi / nl.P
+	th0 := math.Atan(-w / (10 * math.Ln2))
+	breaks := []float64{math.SmallestNonzeroFloat64}
+	for n := math.Ceil(((-1-nl.P/4)*w - th0) / math.Pi); ; n++ {
+		t := (th0+n*math.Pi)/w + 1 + nl.P/4
+		if t >= 1 {
+			break
+		}
+		if t > 0 {
+			breaks = append(breaks, t)
+		}
+	}
+	ts := branchRoots(nl, v, append(breaks, 1))
+	if v == 0 {
+		ts = append([]float64{0}, ts...)
+	}
+	return ts
+}
+
+// InvTransformAll splits f at the ends and peaks of each arc.
+func (nl *NLBounce) InvTransformAll(v float64) []float64 {
+	// Ends and vertices of the arcs of bounceOut, in 1-t
+	xs := []float64{2.625, 2.5, 2.25, 2, 1.5, 1}
+	breaks := []float64{0}
+	for _, x := range xs {
+		breaks = append(breaks, 1-x/bounceD)
+	}
+	return branchRoots(nl, v, append(breaks, 1))
+}
+
+// InvTransformAll maps the solutions for f, which may not be monotonic.
+func (nl *NLOmt) InvTransformAll(v float64) []float64 {
+	ts := InvTransformAll(nl.F, 1-v)
+	for i, j := 0, len(ts)-1; i < j; i, j = i+1, j-1 {
+		ts[i], ts[j] = ts[j], ts[i]
+	}
+	for i, t := range ts {
+		ts[i] = 1 - t
+	}
+	return ts
+}
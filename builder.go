@@ -0,0 +1,192 @@
+package nonlinear
+
+// Builder composes curves left to right, e.g.
+//
+//	Build().Logistic(12, 0.5).Omt().Window(0.1, 0.9).Done()
+//
+// Curve methods apply their curve to the output of the chain so far, and wrapper methods wrap the
+// whole chain so far. As with the constructors, no checks are made on the parameters.
+type Builder struct {
+	f NonLinear
+}
+
+// Build starts an empty chain, equivalent to NLLinear.
+func Build() *Builder {
+	return &Builder{}
+}
+
+// Done returns the composed curve.
+func (b *Builder) Done() NonLinear {
+	if b.f == nil {
+		return &NLLinear{}
+	}
+	return b.f
+}
+
+// Then applies f to the output of the chain.
+func (b *Builder) Then(f NonLinear) *Builder {
+	if b.f == nil {
+		b.f = f
+	} else {
+		b.f = NewNLCompoundV(b.f, f)
+	}
+	return b
+}
+
+func (b *Builder) Linear() *Builder {
+	return b.Then(&NLLinear{})
+}
+
+func (b *Builder) Square() *Builder {
+	return b.Then(&NLSquare{})
+}
+
+func (b *Builder) Cube() *Builder {
+	return b.Then(&NLCube{})
+}
+
+func (b *Builder) Power(p float64) *Builder {
+	return b.Then(NewNLPower(p))
+}
+
+func (b *Builder) Exponential(k float64) *Builder {
+	return b.Then(NewNLExponential(k))
+}
+
+func (b *Builder) Logarithmic(k float64) *Builder {
+	return b.Then(NewNLLogarithmic(k))
+}
+
+func (b *Builder) Sin() *Builder {
+	return b.Then(&NLSin{})
+}
+
+func (b *Builder) Sin1() *Builder {
+	return b.Then(&NLSin1{})
+}
+
+func (b *Builder) Sin2() *Builder {
+	return b.Then(&NLSin2{})
+}
+
+func (b *Builder) Circle1() *Builder {
+	return b.Then(&NLCircle1{})
+}
+
+func (b *Builder) Circle2() *Builder {
+	return b.Then(&NLCircle2{})
+}
+
+func (b *Builder) Lame(n, m float64) *Builder {
+	return b.Then(NewNLLame(n, m))
+}
+
+func (b *Builder) Catenary() *Builder {
+	return b.Then(&NLCatenary{})
+}
+
+func (b *Builder) Gauss(k float64) *Builder {
+	return b.Then(NewNLGauss(k))
+}
+
+func (b *Builder) Logistic(k, mp float64) *Builder {
+	return b.Then(NewNLLogistic(k, mp))
+}
+
+func (b *Builder) P3() *Builder {
+	return b.Then(&NLP3{})
+}
+
+func (b *Builder) P5() *Builder {
+	return b.Then(&NLP5{})
+}
+
+func (b *Builder) Stops(stops [][]float64) *Builder {
+	return b.Then(NewNLStopped(stops))
+}
+
+func (b *Builder) Spline(knots [][]float64) *Builder {
+	return b.Then(NewNLSpline(knots))
+}
+
+func (b *Builder) Polynomial(c ...float64) *Builder {
+	return b.Then(NewNLPolynomial(c...))
+}
+
+func (b *Builder) Spring(stiffness, damping, mass, duration float64) *Builder {
+	return b.Then(NewNLSpring(stiffness, damping, mass, duration))
+}
+
+func (b *Builder) CubicBezier(x1, y1, x2, y2 float64) *Builder {
+	return b.Then(NewNLCubicBezier(x1, y1, x2, y2))
+}
+
+func (b *Builder) Steps(n int, pos StepPosition) *Builder {
+	return b.Then(NewNLSteps(n, pos))
+}
+
+func (b *Builder) Back(c float64) *Builder {
+	return b.Then(NewNLBack(c))
+}
+
+func (b *Builder) Elastic(p float64) *Builder {
+	return b.Then(NewNLElastic(p))
+}
+
+func (b *Builder) Bounce() *Builder {
+	return b.Then(&NLBounce{})
+}
+
+// Omt wraps the chain with NLOmt.
+func (b *Builder) Omt() *Builder {
+	b.f = NewNLOmt(b.Done())
+	return b
+}
+
+// Descend wraps the chain with NLDescend.
+func (b *Builder) Descend() *Builder {
+	b.f = NewNLDescend(b.Done())
+	return b
+}
+
+// Normalized wraps the chain with NLNormalized.
+func (b *Builder) Normalized() *Builder {
+	b.f = NewNLNormalized(b.Done())
+	return b
+}
+
+// Exact wraps the chain with NLExact.
+func (b *Builder) Exact() *Builder {
+	b.f = NewNLExact(b.Done())
+	return b
+}
+
+// Window runs the chain over [t0,t1] with NLWindow.
+func (b *Builder) Window(t0, t1 float64) *Builder {
+	b.f = NewNLWindow(b.Done(), t0, t1)
+	return b
+}
+
+// SoftClamp wraps the chain with NLSoftClamp.
+func (b *Builder) SoftClamp(knee float64) *Builder {
+	b.f = NewNLSoftClamp(b.Done(), knee)
+	return b
+}
+
+// ConstantSpeed wraps the chain with NLConstantSpeed.
+func (b *Builder) ConstantSpeed(samples int) *Builder {
+	b.f = NewNLConstantSpeed(b.Done(), samples)
+	return b
+}
+
+// InOut uses the chain for [0,split] and out for [split,1] with NLInOut.
+func (b *Builder) InOut(out NonLinear, split float64) *Builder {
+	b.f = NewNLInOut(b.Done(), out, split)
+	return b
+}
+
+// Conditional uses the chain below ts and g above with NLConditional.
+func (b *Builder) Conditional(g NonLinear, ts float64) *Builder {
+	b.f = NewNLConditional(b.Done(), g, ts)
+	return b
+}
@@ -0,0 +1,78 @@
+package nonlinear
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// NLCached memoizes f on a grid of Resolution+1 evenly spaced points in [0,1], filled in lazily as
+// they're needed, and interpolates linearly between them. It's safe for concurrent use. Transform
+// outside of [0,1] and InvTransform aren't cached and are passed to f.
+type NLCached struct {
+	F          NonLinear
+	Resolution int
+	cache      []atomic.Uint64 // Float64bits of f(i/Resolution), or notCached
+}
+
+// notCached is a NaN bit pattern that f can't produce through arithmetic, marking empty cache entries.
+const notCached = 0x7ff8dead0000beef
+
+// NewNLCached returns f cached at resolution+1 points. resolution < 1 is treated as 1.
+func NewNLCached(f NonLinear, resolution int) *NLCached {
+	if resolution < 1 {
+		resolution = 1
+	}
+	cache := make([]atomic.Uint64, resolution+1)
+	for i := range cache {
+		cache[i].Store(notCached)
+	}
+	return &NLCached{f, resolution, cache}
+}
+
+// at returns f(i/Resolution), computing and storing it if necessary.
+func (nl *NLCached) at(i int) float64 {
+	b := nl.cache[i].Load()
+	if b != notCached {
+		return math.Float64frombits(b)
+	}
+	v := nl.F.Transform(float64(i) / float64(nl.Resolution))
+	nl.cache[i].Store(math.Float64bits(v))
+	return v
+}
+
+// cell returns the index of the grid cell containing t, and t's position within it.
+func (nl *NLCached) cell(t float64) (int, float64) {
+	x := t * float64(nl.Resolution)
+	i := int(x)
+	if i >= nl.Resolution {
+		i = nl.Resolution - 1
+	}
+	return i, x - float64(i)
+}
+
+func (nl *NLCached) Transform(t float64) float64 {
+	if !(t >= 0 && t <= 1) || len(nl.cache) != nl.Resolution+1 {
+		return nl.F.Transform(t)
+	}
+	i, x := nl.cell(t)
+	return (1-x)*nl.at(i) + x*nl.at(i+1)
+}
+
+func (nl *NLCached) InvTransform(v float64) float64 {
+	return nl.F.InvTransform(v)
+}
+
+func (nl *NLCached) Derivative(t float64) float64 {
+	if !(t >= 0 && t <= 1) || len(nl.cache) != nl.Resolution+1 {
+		return Derivative(nl.F, t)
+	}
+	i, _ := nl.cell(t)
+	return (nl.at(i+1) - nl.at(i)) * float64(nl.Resolution)
+}
+
+func (nl *NLCached) SecondDerivative(t float64) float64 {
+	if !(t >= 0 && t <= 1) || len(nl.cache) != nl.Resolution+1 {
+		return SecondDerivative(nl.F, t)
+	}
+	return 0
+}
@@ -0,0 +1,73 @@
+package nonlinear
+
+// Channels drives several named properties from one shared progress, so that e.g. moving, fading and
+// scaling an element stay in step. Set takes the shared progress, typically the Value of a Tween from
+// 0 to 1 that carries the shared easing, and so can be used directly as an Animator setter:
+//
+//	ch := new(Channels).Add("x", 0, 100, nil, setX).Add("opacity", 0, 1, &NLSquare{}, setOpacity)
+//	animator.Add(NewTween(time.Second, 0, 1, &NLP3{}), ch.Set)
+//
+// The zero value has no channels.
+type Channels struct {
+	chans    []channel
+	progress float64
+}
+
+type channel struct {
+	name     string
+	from, to float64
+	curve    NonLinear
+	set      func(float64)
+	value    float64
+}
+
+// Add adds a channel from from to to. If curve isn't nil, it further eases the shared progress for
+// this channel alone, clamped to [0,1]. Otherwise the shared progress is used as is, including any
+// overshoot of [0,1]. set, if not nil, is called with the channel's value on each Set.
+func (c *Channels) Add(name string, from, to float64, curve NonLinear, set func(float64)) *Channels {
+	c.chans = append(c.chans, channel{name, from, to, curve, set, from})
+	c.update(&c.chans[len(c.chans)-1])
+	return c
+}
+
+// Set sets the shared progress, updating every channel, and calls their setters in the order added.
+func (c *Channels) Set(p float64) {
+	c.progress = p
+	for i := range c.chans {
+		ch := &c.chans[i]
+		c.update(ch)
+		if ch.set != nil {
+			ch.set(ch.value)
+		}
+	}
+}
+
+func (c *Channels) update(ch *channel) {
+	p := c.progress
+	if ch.curve != nil {
+		p = NLerp(p, 0, 1, ch.curve)
+	}
+	ch.value = (1-p)*ch.from + p*ch.to
+}
+
+// Progress returns the shared progress last set.
+func (c *Channels) Progress() float64 {
+	return c.progress
+}
+
+// Value returns the current value of the named channel, or false if there's no such channel.
+func (c *Channels) Value(name string) (float64, bool) {
+	for _, ch := range c.chans {
+		if ch.name == name {
+			return ch.value, true
+		}
+	}
+	return 0, false
+}
+
+// Each calls fn with the name and current value of each channel, in the order added.
+func (c *Channels) Each(fn func(name string, v float64)) {
+	for _, ch := range c.chans {
+		fn(ch.name, ch.value)
+	}
+}
@@ -0,0 +1,116 @@
+package nonlinear
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidParameter is returned (wrapped) by the Checked constructors.
+var ErrInvalidParameter = errors.New("nonlinear: invalid parameter")
+
+func invalidParam(name string, v float64, want string) error {
+	return fmt.Errorf("%w: %s = %g, want %s", ErrInvalidParameter, name, v, want)
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// Must returns f, panicking if err is non-nil. It's for use with the Checked constructors and New
+// where the parameters are constants known to be valid, e.g.
+//
+//	var ease = Must(NewNLLogisticChecked(12, 0.5))
+func Must[T NonLinear](f T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// NewNLExponentialChecked is NewNLExponential with k required to be finite and non-zero, and small
+// enough in magnitude for the scale to be finite and non-zero. Negative k produces a curve that eases
+// out rather than in.
+func NewNLExponentialChecked(k float64) (*NLExponential, error) {
+	if !isFinite(k) || k == 0 {
+		return nil, invalidParam("k", k, "finite and != 0")
+	}
+	nl := NewNLExponential(k)
+	if !isFinite(nl.Scale) || nl.Scale == 0 {
+		return nil, invalidParam("k", k, "with 1/(exp(k)-1) finite and != 0")
+	}
+	return nl, nil
+}
+
+// NewNLLogarithmicChecked is NewNLLogarithmic with k required to be finite, non-zero and > -1.
+func NewNLLogarithmicChecked(k float64) (*NLLogarithmic, error) {
+	if !isFinite(k) || k == 0 || k <= -1 {
+		return nil, invalidParam("k", k, "finite, > -1 and != 0")
+	}
+	return NewNLLogarithmic(k), nil
+}
+
+// NewNLGaussChecked is NewNLGauss with k required to be finite and > 0, and large enough for the
+// offset exp(-k^2/2) to be distinguishable from 1.
+func NewNLGaussChecked(k float64) (*NLGauss, error) {
+	if !isFinite(k) || k <= 0 {
+		return nil, invalidParam("k", k, "finite and > 0")
+	}
+	nl := NewNLGauss(k)
+	if !(nl.Offs < 1) || !isFinite(nl.Scale) {
+		return nil, invalidParam("k", k, "with exp(-k^2/2) < 1")
+	}
+	return nl, nil
+}
+
+// NewNLLogisticChecked is NewNLLogistic with k required to be finite and > 0, and mp in (0,1).
+func NewNLLogisticChecked(k, mp float64) (*NLLogistic, error) {
+	if !isFinite(k) || k <= 0 {
+		return nil, invalidParam("k", k, "finite and > 0")
+	}
+	if !(mp > 0 && mp < 1) {
+		return nil, invalidParam("mp", mp, "in (0,1)")
+	}
+	return NewNLLogistic(k, mp), nil
+}
+
+// NewNLLameChecked is NewNLLame with n and m required to be finite and > 0.
+func NewNLLameChecked(n, m float64) (*NLLame, error) {
+	if !isFinite(n) || n <= 0 {
+		return nil, invalidParam("n", n, "finite and > 0")
+	}
+	if !isFinite(m) || m <= 0 {
+		return nil, invalidParam("m", m, "finite and > 0")
+	}
+	return NewNLLame(n, m), nil
+}
+
+// NewNLNormalizedChecked is NewNLNormalized with f(0) and f(1) required to be finite and to differ,
+// so that the curve doesn't divide by zero.
+func NewNLNormalizedChecked(f NonLinear) (*NLNormalized, error) {
+	v0, v1 := f.Transform(0), f.Transform(1)
+	if !isFinite(v0) {
+		return nil, invalidParam("f(0)", v0, "finite")
+	}
+	if !isFinite(v1) || v1 == v0 {
+		return nil, invalidParam("f(1)", v1, fmt.Sprintf("finite and != f(0) = %g", v0))
+	}
+	return NewNLNormalized(f), nil
+}
+
+// NewNLStoppedChecked is NewNLStoppedStops with the stops required to lie in [0,1] and to be
+// non-decreasing in both t and v, so that the curve is monotonic. Stops may share a t, forming a jump,
+// and may be at t=0 or t=1 in place of the implicit stops there.
+func NewNLStoppedChecked(stops []Stop) (*NLStopped, error) {
+	pt, pv := 0.0, 0.0
+	for i, s := range stops {
+		if !(s.T >= pt && s.T <= 1) {
+			return nil, invalidParam(fmt.Sprintf("stops[%d].T", i), s.T, fmt.Sprintf("in [%g,1]", pt))
+		}
+		if !(s.V >= pv && s.V <= 1) {
+			return nil, invalidParam(fmt.Sprintf("stops[%d].V", i), s.V, fmt.Sprintf("in [%g,1]", pv))
+		}
+		pt, pv = s.T, s.V
+	}
+	return NewNLStoppedStops(stops), nil
+}
@@ -0,0 +1,64 @@
+package nonlinear
+
+import (
+	"math"
+	"time"
+)
+
+// WarpedClock converts real elapsed time into animation time, for slow motion and speed ramps. Real
+// time is scaled by the speed, which may be ramped with an easing, and then, if Warp is set, each
+// Period of it is mapped through Warp, so that e.g. NLP3 slows time at the start and end of every
+// period. Feed the result of Update to the animations, e.g. animator.Update(clock.Update(dt)).
+type WarpedClock struct {
+	Warp   NonLinear
+	Period time.Duration
+
+	speed   Tween   // Speed ramp, in real time
+	clock   float64 // Speed scaled time, in nanoseconds
+	elapsed time.Duration
+}
+
+// NewWarpedClock returns a WarpedClock running at a speed of 1, with warp applied over each period.
+// warp may be nil for no warping.
+func NewWarpedClock(warp NonLinear, period time.Duration) *WarpedClock {
+	return &WarpedClock{Warp: warp, Period: period, speed: Tween{From: 1, To: 1}}
+}
+
+// Update advances the clock by the real time dt and returns the change in animation time. The speed
+// is integrated over dt with the trapezoidal rule.
+func (w *WarpedClock) Update(dt time.Duration) time.Duration {
+	s0 := w.speed.Value()
+	w.speed.Update(dt)
+	w.clock += (s0 + w.speed.Value()) / 2 * float64(dt)
+
+	c := w.clock
+	if w.Warp != nil && w.Period > 0 {
+		p := float64(w.Period)
+		k := math.Floor(c / p)
+		c = (k + w.Warp.Transform(c/p-k)) * p
+	}
+	elapsed := time.Duration(math.Round(c))
+	d := elapsed - w.elapsed
+	w.elapsed = elapsed
+	return d
+}
+
+// Elapsed returns the total animation time.
+func (w *WarpedClock) Elapsed() time.Duration {
+	return w.elapsed
+}
+
+// Speed returns the current speed.
+func (w *WarpedClock) Speed() float64 {
+	return w.speed.Value()
+}
+
+// SetSpeed changes the speed immediately, ending any ramp.
+func (w *WarpedClock) SetSpeed(s float64) {
+	w.speed = Tween{From: s, To: s}
+}
+
+// RampSpeed changes the speed from its current value to s over the real time d, eased by curve.
+func (w *WarpedClock) RampSpeed(s float64, d time.Duration, curve NonLinear) {
+	w.speed = Tween{Duration: d, Curve: curve, From: w.speed.Value(), To: s}
+}
@@ -0,0 +1,241 @@
+package nonlinear
+
+// Cloner is implemented by curves that can make a deep copy of themselves - wrapped curves and
+// stop slices are copied too, so mutating the clone never affects the original.
+type Cloner interface {
+	NonLinear
+	Clone() NonLinear
+}
+
+// Clone returns a deep copy of f if it implements Cloner, otherwise f itself.
+func Clone(f NonLinear) NonLinear {
+	if c, ok := f.(Cloner); ok {
+		return c.Clone()
+	}
+	return f
+}
+
+func (nl *NLLinear) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLSquare) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLCube) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLExponential) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLLogarithmic) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLSin) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLSin1) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLSin2) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLCircle1) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLCircle2) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLLame) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLCatenary) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLGauss) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLLogistic) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLP3) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLP5) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLCubicBezier) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLSteps) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLFunc) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLPower) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLBack) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLElastic) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLBounce) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLCompound) Clone() NonLinear {
+	fs := make([]NonLinear, len(nl.Fs))
+	for i, f := range nl.Fs {
+		fs[i] = Clone(f)
+	}
+	return &NLCompound{fs}
+}
+
+func (nl *NLOmt) Clone() NonLinear {
+	c := *nl
+	c.F = Clone(nl.F)
+	return &c
+}
+
+func (nl *NLNormalized) Clone() NonLinear {
+	c := *nl
+	c.F = Clone(nl.F)
+	return &c
+}
+
+func (nl *NLExact) Clone() NonLinear {
+	return &NLExact{Clone(nl.F)}
+}
+
+func (nl *NLChecked) Clone() NonLinear {
+	return &NLChecked{Clone(nl.F), nl.OnViolation}
+}
+
+func (nl *NLDomain) Clone() NonLinear {
+	return &NLDomain{Clone(nl.F), nl.Mode}
+}
+
+func (nl *NLDescend) Clone() NonLinear {
+	c := *nl
+	c.F = Clone(nl.F)
+	return &c
+}
+
+func (nl *NLSoftClamp) Clone() NonLinear {
+	c := *nl
+	c.F = Clone(nl.F)
+	return &c
+}
+
+func (nl *NLInverse) Clone() NonLinear {
+	c := *nl
+	c.F = Clone(nl.F)
+	return &c
+}
+
+func (nl *NLWindow) Clone() NonLinear {
+	c := *nl
+	c.F = Clone(nl.F)
+	return &c
+}
+
+func (nl *NLStopped) Clone() NonLinear {
+	stops := make([][]float64, len(nl.Stops))
+	for i, s := range nl.Stops {
+		stops[i] = append([]float64(nil), s...)
+	}
+	return NewNLStopped(stops)
+}
+
+func (nl *NLSpline) Clone() NonLinear {
+	knots := make([][]float64, len(nl.Knots))
+	for i, k := range nl.Knots {
+		knots[i] = append([]float64(nil), k...)
+	}
+	return NewNLSpline(knots)
+}
+
+func (nl *NLPolynomial) Clone() NonLinear {
+	return NewNLPolynomial(append([]float64(nil), nl.C...)...)
+}
+
+func (nl *NLSpring) Clone() NonLinear {
+	c := *nl
+	return &c
+}
+
+func (nl *NLConditional) Clone() NonLinear {
+	c := *nl
+	c.F, c.G = Clone(nl.F), Clone(nl.G)
+	return &c
+}
+
+func (nl *NLInOut) Clone() NonLinear {
+	c := *nl
+	c.In, c.Out = Clone(nl.In), Clone(nl.Out)
+	return &c
+}
+
+func (nl *NLConstantSpeed) Clone() NonLinear {
+	return &NLConstantSpeed{Clone(nl.F), append([]float64(nil), nl.T...), append([]float64(nil), nl.S...)}
+}
+
+func (nl *NLTable) Clone() NonLinear {
+	return &NLTable{Clone(nl.F), append([]float64(nil), nl.Vs...), append([]float64(nil), nl.Ts...)}
+}
+
+func (nl *NLInvTable) Clone() NonLinear {
+	return &NLInvTable{Clone(nl.F), append([]float64(nil), nl.Ts...)}
+}
+
+// Clone returns a copy of nl with an empty cache.
+func (nl *NLCached) Clone() NonLinear {
+	return NewNLCached(Clone(nl.F), nl.Resolution)
+}
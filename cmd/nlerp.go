@@ -81,17 +81,7 @@ func graph(nl nonlinear.NonLinear, n int, s string) {
 	xfm := g2d.Translate(100, 900)
 	xfm.Scale(800, -800)
 
-	path := g2d.NewPath([]float64{0, 0})
-	dt := 1.0 / 100
-	t := dt
-	for i := 0; i < 100; i++ {
-		v := nl.Transform(t)
-		path.AddSteps([][]float64{{t, v}})
-		//fmt.Printf("%f => %f\n", t, v)
-		t += dt
-	}
-
-	g2d.DrawPath(img, path.Transform(xfm), g2d.BlackPen)
+	g2d.DrawPath(img, nonlinear.CurvePath(nl, 100, xfm), g2d.BlackPen)
 
 	// Capture image output
 	image.SaveImage(img, fmt.Sprintf("nlerp-%d %s", n, s))
@@ -0,0 +1,86 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"text/template"
+
+	"github.com/jphsd/nonlinear"
+)
+
+var goTemplate = template.Must(template.New("go").Funcs(template.FuncMap{
+	"mod": func(a, b int) int { return a % b },
+}).Parse(`// Code generated by github.com/jphsd/nonlinear/codegen. DO NOT EDIT.
+
+package {{.Pkg}}
+
+import "sort"
+
+// {{.Name}} is a table driven approximation of {{.Desc}} using {{.N}} samples.
+// It implements nonlinear.NonLinear.
+type {{.Name}} struct{}
+
+var {{.Table}} = [{{.N}}]float64{
+{{- range $i, $v := .Values}}{{if eq 0 (mod $i 4)}}
+	{{end}}{{$v}},{{end}}
+}
+
+func (nl {{.Name}}) Transform(t float64) float64 {
+	if t <= 0 {
+		return {{.Table}}[0]
+	}
+	if t >= 1 {
+		return {{.Table}}[{{.N}}-1]
+	}
+	x := t * ({{.N}} - 1)
+	i := int(x)
+	x -= float64(i)
+	return (1-x)*{{.Table}}[i] + x*{{.Table}}[i+1]
+}
+
+func (nl {{.Name}}) InvTransform(v float64) float64 {
+	i := sort.SearchFloat64s({{.Table}}[:], v)
+	if i == 0 {
+		return 0
+	}
+	if i == {{.N}} {
+		return 1
+	}
+	v0, v1 := {{.Table}}[i-1], {{.Table}}[i]
+	x := (v - v0) / (v1 - v0)
+	return (float64(i-1) + x) / ({{.N}} - 1)
+}
+`))
+
+// GoSource returns a gofmt'd Go source file for package pkg declaring a type called name, with
+// Transform and InvTransform methods, that approximates f with a table of n samples (n >= 2).
+// The file depends only on the standard library. f should be monotonic for InvTransform to be valid.
+func GoSource(pkg, name string, f nonlinear.NonLinear, n int) ([]byte, error) {
+	if n < 2 {
+		n = 2
+	}
+	vals := make([]string, n)
+	for i := range vals {
+		vals[i] = strconv.FormatFloat(f.Transform(float64(i)/float64(n-1)), 'g', -1, 64)
+	}
+	desc := fmt.Sprintf("%T", f)
+	if s, ok := f.(fmt.Stringer); ok {
+		desc = s.String()
+	}
+
+	var buf bytes.Buffer
+	err := goTemplate.Execute(&buf, map[string]any{
+		"Pkg":    pkg,
+		"Name":   name,
+		"Table":  name + "Table",
+		"Desc":   desc,
+		"N":      n,
+		"Values": vals,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
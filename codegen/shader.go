@@ -0,0 +1,478 @@
+// Package codegen generates source code implementing curves from package nonlinear, so that the
+// same easing can be evaluated on the GPU or without depending on the package.
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jphsd/nonlinear"
+)
+
+// Language selects the shading language emitted by Shader and ShaderLUT.
+type Language int
+
+const (
+	GLSL Language = iota
+	WGSL
+	HLSL
+)
+
+// ErrUnsupported is returned (wrapped) by Shader for curves that have no closed form translation.
+// ShaderLUT can be used for these instead.
+var ErrUnsupported = errors.New("codegen: unsupported curve")
+
+// Shader returns source for a function called name, taking and returning a float, that implements
+// f. Combinators produce one helper function per wrapped curve, named name_0, name_1, etc. and
+// emitted before the main function.
+func Shader(lang Language, name string, f nonlinear.NonLinear) (string, error) {
+	g := &shaderGen{lang: lang, name: name}
+	if _, err := g.curve(f, name); err != nil {
+		return "", err
+	}
+	if g.err != nil {
+		return "", g.err
+	}
+	return g.sb.String(), nil
+}
+
+// ShaderLUT returns source for a function called name that looks up f in a table of n samples,
+// n >= 2, with linear interpolation. The table is called name_lut. It's an error for a sample not to
+// be representable as a finite float.
+func ShaderLUT(lang Language, name string, f nonlinear.NonLinear, n int) (string, error) {
+	if n < 2 {
+		n = 2
+	}
+	vals := make([]string, n)
+	for i := range vals {
+		t := float64(i) / float64(n-1)
+		v := f.Transform(t)
+		if !finite32(v) {
+			return "", fmt.Errorf("codegen: f(%g) = %g can't be written as a float", t, v)
+		}
+		vals[i] = lit(v)
+	}
+	list := strings.Join(vals, ", ")
+	var sb strings.Builder
+	switch lang {
+	case WGSL:
+		fmt.Fprintf(&sb, "var<private> %s_lut: array<f32, %d> = array<f32, %d>(%s);\n\n", name, n, n, list)
+		fmt.Fprintf(&sb, "fn %s(t: f32) -> f32 {\n", name)
+		fmt.Fprintf(&sb, "\tlet x = clamp(t, 0.0, 1.0) * %d.0;\n", n-1)
+		fmt.Fprintf(&sb, "\tlet i = u32(min(floor(x), %d.0));\n", n-2)
+		fmt.Fprintf(&sb, "\treturn mix(%s_lut[i], %s_lut[i + 1u], x - f32(i));\n}\n", name, name)
+	case HLSL:
+		fmt.Fprintf(&sb, "static const float %s_lut[%d] = { %s };\n\n", name, n, list)
+		fmt.Fprintf(&sb, "float %s(float t) {\n", name)
+		fmt.Fprintf(&sb, "\tfloat x = saturate(t) * %d.0;\n", n-1)
+		fmt.Fprintf(&sb, "\tint i = (int)min(floor(x), %d.0);\n", n-2)
+		fmt.Fprintf(&sb, "\treturn lerp(%s_lut[i], %s_lut[i + 1], x - (float)i);\n}\n", name, name)
+	default:
+		fmt.Fprintf(&sb, "const float %s_lut[%d] = float[%d](%s);\n\n", name, n, n, list)
+		fmt.Fprintf(&sb, "float %s(float t) {\n", name)
+		fmt.Fprintf(&sb, "\tfloat x = clamp(t, 0.0, 1.0) * %d.0;\n", n-1)
+		fmt.Fprintf(&sb, "\tint i = int(min(floor(x), %d.0));\n", n-2)
+		fmt.Fprintf(&sb, "\treturn mix(%s_lut[i], %s_lut[i + 1], x - float(i));\n}\n", name, name)
+	}
+	return sb.String(), nil
+}
+
+// lit formats v as a floating point literal valid in all three languages, which have no literals for
+// infinities or NaN, see finite32.
+func lit(v float64) string {
+	s := strconv.FormatFloat(v, 'g', -1, 32)
+	if !strings.ContainsAny(s, ".eEn") {
+		s += ".0"
+	}
+	if v < 0 {
+		s = "(" + s + ")"
+	}
+	return s
+}
+
+// finite32 returns true if v is finite as a float32.
+func finite32(v float64) bool {
+	f := float64(float32(v))
+	return !math.IsInf(f, 0) && !math.IsNaN(f)
+}
+
+type shaderGen struct {
+	lang Language
+	name string
+	n    int
+	sb   strings.Builder
+	err  error // The first constant that couldn't be written
+}
+
+// lit is lit, recording an error for constants that aren't finite as a float32.
+func (g *shaderGen) lit(v float64) string {
+	if !finite32(v) && g.err == nil {
+		g.err = fmt.Errorf("codegen: %s needs the constant %g, which can't be written as a float", g.name, v)
+	}
+	return lit(v)
+}
+
+// helper allocates the name of a function for a wrapped curve and emits it.
+func (g *shaderGen) helper(f nonlinear.NonLinear) (string, error) {
+	name := fmt.Sprintf("%s_%d", g.name, g.n)
+	g.n++
+	return g.curve(f, name)
+}
+
+// function emits a function called name with the supplied body statements.
+func (g *shaderGen) function(name string, body ...string) {
+	if g.lang == WGSL {
+		fmt.Fprintf(&g.sb, "fn %s(t: f32) -> f32 {\n", name)
+	} else {
+		fmt.Fprintf(&g.sb, "float %s(float t) {\n", name)
+	}
+	for _, s := range body {
+		fmt.Fprintf(&g.sb, "\t%s\n", s)
+	}
+	g.sb.WriteString("}\n\n")
+}
+
+func ret(e string) string {
+	return "return " + e + ";"
+}
+
+func (g *shaderGen) sel(cond, a, b string) string {
+	if g.lang == WGSL {
+		return fmt.Sprintf("select(%s, %s, %s)", b, a, cond)
+	}
+	return fmt.Sprintf("((%s) ? %s : %s)", cond, a, b)
+}
+
+func (g *shaderGen) mix(a, b, x string) string {
+	if g.lang == HLSL {
+		return fmt.Sprintf("lerp(%s, %s, %s)", a, b, x)
+	}
+	return fmt.Sprintf("mix(%s, %s, %s)", a, b, x)
+}
+
+func (g *shaderGen) local(name, e string) string {
+	if g.lang == WGSL {
+		return fmt.Sprintf("var %s = %s;", name, e)
+	}
+	return fmt.Sprintf("float %s = %s;", name, e)
+}
+
+func (g *shaderGen) curve(f nonlinear.NonLinear, name string) (string, error) {
+	switch nl := f.(type) {
+	case *nonlinear.NLLinear:
+		g.function(name, ret("t"))
+	case *nonlinear.NLSquare:
+		g.function(name, ret("t * t"))
+	case *nonlinear.NLCube:
+		g.function(name, ret("t * t * t"))
+	case *nonlinear.NLExponential:
+		g.function(name, ret(fmt.Sprintf("(exp(t * %s) - 1.0) * %s", g.lit(nl.K), g.lit(nl.Scale))))
+	case *nonlinear.NLLogarithmic:
+		g.function(name, ret(fmt.Sprintf("log(1.0 + t * %s) * %s", g.lit(nl.K), g.lit(nl.Scale))))
+	case *nonlinear.NLSin:
+		g.function(name, ret(fmt.Sprintf("(sin((t - 0.5) * %s) + 1.0) * 0.5", g.lit(math.Pi))))
+	case *nonlinear.NLSin1:
+		g.function(name, ret(fmt.Sprintf("sin(t * %s)", g.lit(math.Pi/2))))
+	case *nonlinear.NLSin2:
+		g.function(name, ret(fmt.Sprintf("sin((t - 1.0) * %s) + 1.0", g.lit(math.Pi/2))))
+	case *nonlinear.NLCircle1:
+		g.function(name, ret("1.0 - sqrt(max(1.0 - t * t, 0.0))"))
+	case *nonlinear.NLCircle2:
+		g.function(name, ret("sqrt(max(t * (2.0 - t), 0.0))"))
+	case *nonlinear.NLLame:
+		g.function(name, ret(fmt.Sprintf("1.0 - pow(max(1.0 - pow(t, %s), 0.0), %s)", g.lit(nl.N), g.lit(nl.Odm))))
+	case *nonlinear.NLCatenary:
+		g.function(name, ret(fmt.Sprintf("(cosh(t) - 1.0) * %s", g.lit(1/(math.Cosh(1)-1)))))
+	case *nonlinear.NLGauss:
+		g.function(name, ret(fmt.Sprintf("(exp(%s * (t - 1.0) * (t - 1.0)) - %s) * %s",
+			g.lit(-0.5*nl.K*nl.K), g.lit(nl.Offs), g.lit(nl.Scale))))
+	case *nonlinear.NLLogistic:
+		g.function(name, ret(fmt.Sprintf("(1.0 / (1.0 + exp((%s - t) * %s)) - %s) * %s",
+			g.lit(nl.Mp), g.lit(nl.K), g.lit(nl.Offs), g.lit(nl.Scale))))
+	case *nonlinear.NLP3:
+		g.function(name, ret("t * t * (3.0 - 2.0 * t)"))
+	case *nonlinear.NLP5:
+		g.function(name, ret("t * t * t * (t * (t * 6.0 - 15.0) + 10.0)"))
+	case *nonlinear.NLPower:
+		g.function(name, ret(fmt.Sprintf("pow(t, %s)", g.lit(nl.P))))
+	case *nonlinear.NLBack:
+		g.function(name, ret(fmt.Sprintf("t * t * (%s * t - %s)", g.lit(nl.C+1), g.lit(nl.C))))
+	case *nonlinear.NLElastic:
+		g.function(name, ret(g.sel("t <= 0.0", "0.0", fmt.Sprintf("-exp2(10.0 * (t - 1.0)) * sin((t - %s) * %s)",
+			g.lit(1+nl.P/4), g.lit(2*math.Pi/nl.P)))))
+	case *nonlinear.NLBounce:
+		g.bounce(name)
+	case *nonlinear.NLCubicBezier:
+		g.bezier(name, nl)
+	case *nonlinear.NLStopped:
+		g.stopped(name, nl)
+	case *nonlinear.NLSpline:
+		g.spline(name, nl)
+	case *nonlinear.NLSpring:
+		g.spring(name, nl)
+	case *nonlinear.NLPolynomial:
+		e := "0.0"
+		if n := len(nl.C); n > 0 {
+			// Horner's method, innermost term first
+			e = g.lit(nl.C[n-1])
+			for i := n - 2; i >= 0; i-- {
+				e = fmt.Sprintf("%s + t * %s", g.lit(nl.C[i]), e)
+				if i > 0 {
+					e = "(" + e + ")"
+				}
+			}
+		}
+		g.function(name, ret(e))
+	case *nonlinear.NLSteps:
+		j, off := nl.N, 0
+		switch nl.Position {
+		case nonlinear.JumpNone:
+			j = nl.N - 1
+		case nonlinear.JumpStart:
+			off = 1
+		case nonlinear.JumpBoth:
+			j, off = nl.N+1, 1
+		}
+		g.function(name, ret(fmt.Sprintf("clamp(floor(t * %d.0) + %d.0, 0.0, %d.0) / %d.0", nl.N, off, j, j)))
+	case *nonlinear.NLTable:
+		lut, err := ShaderLUT(g.lang, name, nl, len(nl.Vs))
+		if err != nil {
+			return "", err
+		}
+		g.sb.WriteString(lut)
+		g.sb.WriteString("\n")
+	case *nonlinear.NLCached:
+		return g.curve(nl.F, name)
+	case *nonlinear.NLInvTable:
+		return g.curve(nl.F, name)
+	case *nonlinear.NLCompound:
+		e := "t"
+		for _, c := range nl.Fs {
+			h, err := g.helper(c)
+			if err != nil {
+				return "", err
+			}
+			e = fmt.Sprintf("%s(%s)", h, e)
+		}
+		g.function(name, ret(e))
+	case *nonlinear.NLOmt:
+		h, err := g.helper(nl.F)
+		if err != nil {
+			return "", err
+		}
+		g.function(name, ret(fmt.Sprintf("1.0 - %s(1.0 - t)", h)))
+	case *nonlinear.NLDescend:
+		h, err := g.helper(nl.F)
+		if err != nil {
+			return "", err
+		}
+		g.function(name, ret(fmt.Sprintf("1.0 - %s(t)", h)))
+	case *nonlinear.NLWindow:
+		h, err := g.helper(nl.F)
+		if err != nil {
+			return "", err
+		}
+		g.function(name, ret(fmt.Sprintf("%s(clamp((t - %s) / %s, 0.0, 1.0))", h, g.lit(nl.A), g.lit(nl.B-nl.A))))
+	case *nonlinear.NLNormalized:
+		h, err := g.helper(nl.F)
+		if err != nil {
+			return "", err
+		}
+		g.function(name, ret(fmt.Sprintf("(%s(t) - %s) * %s", h, g.lit(nl.V0), g.lit(1/(nl.V1-nl.V0)))))
+	case *nonlinear.NLExact:
+		h, err := g.helper(nl.F)
+		if err != nil {
+			return "", err
+		}
+		g.function(name, "if (t == 0.0) { "+ret("0.0")+" }", "if (t == 1.0) { "+ret("1.0")+" }", ret(h+"(t)"))
+	case *nonlinear.NLDomain:
+		h, err := g.helper(nl.F)
+		if err != nil {
+			return "", err
+		}
+		switch nl.Mode {
+		case nonlinear.Extend:
+			v0, d0 := nl.F.Transform(0), nonlinear.Derivative(nl.F, 0)
+			v1, d1 := nl.F.Transform(1), nonlinear.Derivative(nl.F, 1)
+			g.function(name,
+				fmt.Sprintf("if (t < 0.0) { %s }", ret(fmt.Sprintf("%s + t * %s", g.lit(v0), g.lit(d0)))),
+				fmt.Sprintf("if (t > 1.0) { %s }", ret(fmt.Sprintf("%s + (t - 1.0) * %s", g.lit(v1), g.lit(d1)))),
+				ret(h+"(t)"))
+		case nonlinear.Wrap:
+			g.function(name,
+				fmt.Sprintf("if (t > 1.0) { %s }", ret(fmt.Sprintf("%s(t + 1.0 - ceil(t))", h))),
+				fmt.Sprintf("if (t < 0.0) { %s }", ret(fmt.Sprintf("%s(t - floor(t))", h))),
+				ret(h+"(t)"))
+		case nonlinear.Mirror:
+			g.function(name, ret(fmt.Sprintf("%s(1.0 - abs(1.0 - (t - 2.0 * floor(t * 0.5))))", h)))
+		default:
+			g.function(name, ret(fmt.Sprintf("%s(clamp(t, 0.0, 1.0))", h)))
+		}
+	case *nonlinear.NLInverse:
+		h, err := g.helper(nl.F)
+		if err != nil {
+			return "", err
+		}
+		g.function(name, ret(fmt.Sprintf("%s(t)", h)))
+	case *nonlinear.NLConditional:
+		hf, err := g.helper(nl.F)
+		if err != nil {
+			return "", err
+		}
+		if nl.Ts >= 1 {
+			g.function(name, ret(fmt.Sprintf("%s(t)", hf)))
+			break
+		}
+		hg, err := g.helper(nl.G)
+		if err != nil {
+			return "", err
+		}
+		g.function(name, ret(g.sel("t < "+g.lit(nl.Ts),
+			fmt.Sprintf("%s(t)", hf),
+			fmt.Sprintf("%s + %s * %s((t - %s) * %s)", g.lit(nl.Vs), g.lit(1-nl.Vs), hg, g.lit(nl.Ts), g.lit(1/(1-nl.Ts))))))
+	case *nonlinear.NLInOut:
+		s := nl.Split
+		hi, err := g.helper(nl.In)
+		if err != nil {
+			return "", err
+		}
+		ho, err := g.helper(nl.Out)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case s <= 0:
+			g.function(name, ret(fmt.Sprintf("%s(t)", ho)))
+		case s >= 1:
+			g.function(name, ret(fmt.Sprintf("%s(t)", hi)))
+		default:
+			g.function(name, ret(g.sel("t < "+g.lit(s),
+				fmt.Sprintf("%s * %s(t * %s)", g.lit(s), hi, g.lit(1/s)),
+				fmt.Sprintf("%s + %s * %s((t - %s) * %s)", g.lit(s), g.lit(1-s), ho, g.lit(s), g.lit(1/(1-s))))))
+		}
+	default:
+		return "", fmt.Errorf("%w: %T", ErrUnsupported, f)
+	}
+	return name, nil
+}
+
+// bezier emits a fixed number of Newton iterations to solve x(u) = t, followed by y(u).
+func (g *shaderGen) bezier(name string, nl *nonlinear.NLCubicBezier) {
+	cx := 3 * nl.X1
+	bx := 3*(nl.X2-nl.X1) - cx
+	ax := 1 - cx - bx
+	cy := 3 * nl.Y1
+	by := 3*(nl.Y2-nl.Y1) - cy
+	ay := 1 - cy - by
+	x := fmt.Sprintf("((%s * u + %s) * u + %s) * u - t", g.lit(ax), g.lit(bx), g.lit(cx))
+	d := fmt.Sprintf("(%s * u + %s) * u + %s", g.lit(3*ax), g.lit(2*bx), g.lit(cx))
+	y := fmt.Sprintf("((%s * u + %s) * u + %s) * u", g.lit(ay), g.lit(by), g.lit(cy))
+	loop := "for (int i = 0; i < 8; i++) {"
+	if g.lang == WGSL {
+		loop = "for (var i = 0; i < 8; i++) {"
+	}
+	g.function(name,
+		g.local("u", "t"),
+		loop,
+		"\t"+g.local("d", d),
+		"\tif (abs(d) < 1e-6) { break; }",
+		"\tu = clamp(u - ("+x+") / d, 0.0, 1.0);",
+		"}",
+		ret(y))
+}
+
+// stopped emits one test per segment, including the implied segments from (0,0) and to (1,1).
+func (g *shaderGen) stopped(name string, nl *nonlinear.NLStopped) {
+	pts := [][]float64{{0, 0}}
+	if len(nl.Stops) > 0 && nl.Stops[0][0] <= 0 {
+		pts = nil
+	}
+	pts = append(pts, nl.Stops...)
+	if pts[len(pts)-1][0] < 1 {
+		pts = append(pts, []float64{1, 1})
+	}
+	var body []string
+	for i := 1; i < len(pts); i++ {
+		p0, p1 := pts[i-1], pts[i]
+		if p1[0] <= p0[0] {
+			continue
+		}
+		x := fmt.Sprintf("(t - %s) * %s", g.lit(p0[0]), g.lit(1/(p1[0]-p0[0])))
+		body = append(body, fmt.Sprintf("if (t < %s) { %s }", g.lit(p1[0]), ret(g.mix(g.lit(p0[1]), g.lit(p1[1]), x))))
+	}
+	body = append(body, ret(g.lit(pts[len(pts)-1][1])))
+	g.function(name, body...)
+}
+
+// spline emits one cubic per segment, as v0 + s * (c1 + s * (c2 + s * c3)) with s in [0,1], and the
+// end tangents beyond the knots.
+func (g *shaderGen) spline(name string, nl *nonlinear.NLSpline) {
+	var xs, vs, ms []float64
+	if len(nl.Knots) == 0 || nl.Knots[0][0] > 0 {
+		xs, vs = append(xs, 0), append(vs, 0)
+	}
+	for _, k := range nl.Knots {
+		xs, vs = append(xs, k[0]), append(vs, k[1])
+	}
+	if xs[len(xs)-1] < 1 {
+		xs, vs = append(xs, 1), append(vs, 1)
+	}
+	// The derivative at a knot is its tangent
+	for _, x := range xs {
+		ms = append(ms, nl.Derivative(x))
+	}
+	n := len(xs)
+	body := []string{fmt.Sprintf("if (t < %s) { %s }", g.lit(xs[0]),
+		ret(fmt.Sprintf("%s + (t - %s) * %s", g.lit(vs[0]), g.lit(xs[0]), g.lit(ms[0]))))}
+	for i := 0; i+1 < n; i++ {
+		h := xs[i+1] - xs[i]
+		dv := vs[i+1] - vs[i]
+		c1, c2, c3 := h*ms[i], 3*dv-2*h*ms[i]-h*ms[i+1], -2*dv+h*ms[i]+h*ms[i+1]
+		body = append(body, fmt.Sprintf("if (t < %s) { %s %s }", g.lit(xs[i+1]),
+			g.local("s", fmt.Sprintf("(t - %s) * %s", g.lit(xs[i]), g.lit(1/h))),
+			ret(fmt.Sprintf("%s + s * (%s + s * (%s + s * %s))", g.lit(vs[i]), g.lit(c1), g.lit(c2), g.lit(c3)))))
+	}
+	body = append(body, ret(fmt.Sprintf("%s + (t - %s) * %s", g.lit(vs[n-1]), g.lit(xs[n-1]), g.lit(ms[n-1]))))
+	g.function(name, body...)
+}
+
+// spring emits the closed form solution for the spring's damping, with the time scaled by Duration.
+func (g *shaderGen) spring(name string, nl *nonlinear.NLSpring) {
+	m := nl.Mass
+	if m <= 0 {
+		m = 1
+	}
+	w0 := math.Sqrt(nl.Stiffness / m)
+	zeta := nl.Damping / (2 * math.Sqrt(nl.Stiffness*m))
+	x := g.local("x", "t * "+g.lit(nl.Duration))
+	switch {
+	case zeta < 1:
+		a, wd := zeta*w0, w0*math.Sqrt(1-zeta*zeta)
+		g.function(name, x, ret(fmt.Sprintf("1.0 - exp(-%s * x) * (cos(%s * x) + %s * sin(%s * x))",
+			g.lit(a), g.lit(wd), g.lit(a/wd), g.lit(wd))))
+	case zeta == 1:
+		g.function(name, x, ret(fmt.Sprintf("1.0 - exp(-%s * x) * (1.0 + %s * x)", g.lit(w0), g.lit(w0))))
+	default:
+		q := zeta + math.Sqrt((zeta-1)*(zeta+1))
+		r1, r2 := -w0/q, -w0*q
+		c2 := -r1 / (r2 - r1)
+		g.function(name, x, ret(fmt.Sprintf("1.0 - %s * exp(%s * x) - %s * exp(%s * x)",
+			g.lit(1-c2), g.lit(r1), g.lit(c2), g.lit(r2))))
+	}
+}
+
+// bounce emits Penner's easeOutBounce mirrored as for NLBounce.
+func (g *shaderGen) bounce(name string) {
+	const n, d = 7.5625, 2.75
+	body := []string{g.local("x", "1.0 - t")}
+	arcs := []struct{ x, c, h float64 }{{1 / d, 0, 0}, {2 / d, 1.5 / d, 0.75}, {2.5 / d, 2.25 / d, 0.9375}}
+	for _, a := range arcs {
+		body = append(body, fmt.Sprintf("if (x < %s) { x = x - %s; return %s - %s * x * x; }",
+			g.lit(a.x), g.lit(a.c), g.lit(1-a.h), g.lit(n)))
+	}
+	body = append(body, fmt.Sprintf("x = x - %s;", g.lit(2.625/d)), ret(fmt.Sprintf("%s - %s * x * x", g.lit(1-0.984375), g.lit(n))))
+	g.function(name, body...)
+}
@@ -0,0 +1,28 @@
+package codegen
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jphsd/nonlinear"
+)
+
+func TestShaderNonFinite(t *testing.T) {
+	// f(1) - f(0) overflows, so the normalization's scale is 0 and its inverse infinite
+	f := nonlinear.NewNLNormalized(nonlinear.NewNLPolynomial(0, 1e-300))
+	inf := nonlinear.NewNLPolynomial(0, math.Inf(1))
+	for _, lang := range []Language{GLSL, WGSL, HLSL} {
+		if _, err := Shader(lang, "ease", inf); err == nil {
+			t.Errorf("Shader(%d) of %v succeeded, want an error", lang, inf)
+		}
+		if _, err := Shader(lang, "ease", f); err == nil {
+			t.Errorf("Shader(%d) of %v succeeded, want an error", lang, f)
+		}
+		if _, err := ShaderLUT(lang, "ease", nonlinear.NewNLPolynomial(0, 1e39), 16); err == nil {
+			t.Errorf("ShaderLUT(%d) succeeded, want an error", lang)
+		}
+		if _, err := Shader(lang, "ease", nonlinear.NewNLPolynomial(0, 0, 3, -2)); err != nil {
+			t.Errorf("Shader(%d): %v", lang, err)
+		}
+	}
+}
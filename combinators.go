@@ -0,0 +1,247 @@
+package nonlinear
+
+import "math"
+
+// NLConditional v = f(t) for t < ts, and g scaled to fit in [ts,1] x [f(ts),1] otherwise,
+// so that the result is continuous at ts.
+type NLConditional struct {
+	F, G NonLinear
+	Ts   float64 // Threshold in [0,1]
+	Vs   float64 // f(ts)
+}
+
+func NewNLConditional(f, g NonLinear, ts float64) *NLConditional {
+	return &NLConditional{f, g, ts, f.Transform(ts)}
+}
+
+func (nl *NLConditional) Transform(t float64) float64 {
+	if t < nl.Ts || nl.Ts >= 1 {
+		return nl.F.Transform(t)
+	}
+	t = (t - nl.Ts) / (1 - nl.Ts)
+	return nl.Vs + (1-nl.Vs)*nl.G.Transform(t)
+}
+
+func (nl *NLConditional) InvTransform(v float64) float64 {
+	if v < nl.Vs || nl.Ts >= 1 {
+		return nl.F.InvTransform(v)
+	}
+	if nl.Vs >= 1 {
+		return nl.Ts
+	}
+	v = (v - nl.Vs) / (1 - nl.Vs)
+	return nl.Ts + (1-nl.Ts)*nl.G.InvTransform(v)
+}
+
+func (nl *NLConditional) Derivative(t float64) float64 {
+	if t < nl.Ts || nl.Ts >= 1 {
+		return Derivative(nl.F, t)
+	}
+	t = (t - nl.Ts) / (1 - nl.Ts)
+	return (1 - nl.Vs) / (1 - nl.Ts) * Derivative(nl.G, t)
+}
+
+func (nl *NLConditional) SecondDerivative(t float64) float64 {
+	if t < nl.Ts || nl.Ts >= 1 {
+		return SecondDerivative(nl.F, t)
+	}
+	dt := 1 - nl.Ts
+	t = (t - nl.Ts) / dt
+	return (1 - nl.Vs) / (dt * dt) * SecondDerivative(nl.G, t)
+}
+
+// NLNormalized v = (f(t) - f(0)) / (f(1) - f(0)) which is exactly 0 at t=0 and 1 at t=1. f(0) must
+// differ from f(1), see NewNLNormalizedChecked.
+type NLNormalized struct {
+	F      NonLinear
+	V0, V1 float64 // f(0) and f(1)
+}
+
+func NewNLNormalized(f NonLinear) *NLNormalized {
+	return &NLNormalized{f, f.Transform(0), f.Transform(1)}
+}
+
+func (nl *NLNormalized) Transform(t float64) float64 {
+	return (nl.F.Transform(t) - nl.V0) / (nl.V1 - nl.V0)
+}
+
+func (nl *NLNormalized) InvTransform(v float64) float64 {
+	return nl.F.InvTransform(v*(nl.V1-nl.V0) + nl.V0)
+}
+
+func (nl *NLNormalized) Derivative(t float64) float64 {
+	return Derivative(nl.F, t) / (nl.V1 - nl.V0)
+}
+
+func (nl *NLNormalized) SecondDerivative(t float64) float64 {
+	return SecondDerivative(nl.F, t) / (nl.V1 - nl.V0)
+}
+
+func (nl *NLNormalized) Integral(a, b float64) float64 {
+	return (Integral(nl.F, a, b) - nl.V0*(b-a)) / (nl.V1 - nl.V0)
+}
+
+// NLExact v = f(t) other than at t = 0 and t = 1, where v is exactly 0 and 1. This removes the last
+// bit drift of curves normalized by a computed scale, such as NLGauss and NLLogistic, without
+// changing f elsewhere as NLNormalized does.
+type NLExact struct {
+	F NonLinear
+}
+
+func NewNLExact(f NonLinear) *NLExact {
+	return &NLExact{f}
+}
+
+func (nl *NLExact) Transform(t float64) float64 {
+	switch t {
+	case 0:
+		return 0
+	case 1:
+		return 1
+	}
+	return nl.F.Transform(t)
+}
+
+func (nl *NLExact) InvTransform(v float64) float64 {
+	return nl.F.InvTransform(v)
+}
+
+func (nl *NLExact) Derivative(t float64) float64 {
+	return Derivative(nl.F, t)
+}
+
+func (nl *NLExact) SecondDerivative(t float64) float64 {
+	return SecondDerivative(nl.F, t)
+}
+
+func (nl *NLExact) Integral(a, b float64) float64 {
+	return Integral(nl.F, a, b)
+}
+
+// NLInOut v = split * in(t/split) for t < split, and split + (1-split) * out((t-split)/(1-split))
+// otherwise. The two halves meet at (split, split).
+type NLInOut struct {
+	In, Out NonLinear
+	Split   float64
+}
+
+func NewNLInOut(in, out NonLinear, split float64) *NLInOut {
+	return &NLInOut{in, out, split}
+}
+
+func (nl *NLInOut) Transform(t float64) float64 {
+	s := nl.Split
+	if t < s || s >= 1 {
+		return s * nl.In.Transform(t/s)
+	}
+	return s + (1-s)*nl.Out.Transform((t-s)/(1-s))
+}
+
+func (nl *NLInOut) InvTransform(v float64) float64 {
+	s := nl.Split
+	if v < s || s >= 1 {
+		return s * nl.In.InvTransform(v/s)
+	}
+	return s + (1-s)*nl.Out.InvTransform((v-s)/(1-s))
+}
+
+func (nl *NLInOut) Derivative(t float64) float64 {
+	s := nl.Split
+	if t < s || s >= 1 {
+		return Derivative(nl.In, t/s)
+	}
+	return Derivative(nl.Out, (t-s)/(1-s))
+}
+
+func (nl *NLInOut) SecondDerivative(t float64) float64 {
+	s := nl.Split
+	if t < s || s >= 1 {
+		return SecondDerivative(nl.In, t/s) / s
+	}
+	return SecondDerivative(nl.Out, (t-s)/(1-s)) / (1 - s)
+}
+
+// NLDescend v = 1 - f(t), a decreasing function mapping 0 -> 1 and 1 -> 0. For use with
+// NLerpDescending and InvNLerpDescending.
+type NLDescend struct {
+	F NonLinear
+}
+
+func NewNLDescend(f NonLinear) *NLDescend {
+	return &NLDescend{f}
+}
+
+func (nl *NLDescend) Transform(t float64) float64 {
+	return 1 - nl.F.Transform(t)
+}
+
+func (nl *NLDescend) InvTransform(v float64) float64 {
+	return nl.F.InvTransform(1 - v)
+}
+
+func (nl *NLDescend) Derivative(t float64) float64 {
+	return -Derivative(nl.F, t)
+}
+
+func (nl *NLDescend) SecondDerivative(t float64) float64 {
+	return -SecondDerivative(nl.F, t)
+}
+
+func (nl *NLDescend) Integral(a, b float64) float64 {
+	return b - a - Integral(nl.F, a, b)
+}
+
+// NLWindow v = f((t-a)/(b-a)) with the argument clamped to [0,1], so that f runs over [a,b] and v is
+// held at f(0) before a and f(1) after b. InvTransform always returns a t in [a,b].
+type NLWindow struct {
+	F    NonLinear
+	A, B float64
+}
+
+func NewNLWindow(f NonLinear, a, b float64) *NLWindow {
+	return &NLWindow{f, a, b}
+}
+
+// u maps t into f's domain.
+func (nl *NLWindow) u(t float64) float64 {
+	return math.Min(1, math.Max(0, (t-nl.A)/(nl.B-nl.A)))
+}
+
+func (nl *NLWindow) Transform(t float64) float64 {
+	return nl.F.Transform(nl.u(t))
+}
+
+func (nl *NLWindow) InvTransform(v float64) float64 {
+	return nl.A + (nl.B-nl.A)*nl.F.InvTransform(v)
+}
+
+func (nl *NLWindow) Derivative(t float64) float64 {
+	if t < nl.A || t > nl.B {
+		return 0
+	}
+	return Derivative(nl.F, nl.u(t)) / (nl.B - nl.A)
+}
+
+func (nl *NLWindow) SecondDerivative(t float64) float64 {
+	if t < nl.A || t > nl.B {
+		return 0
+	}
+	w := nl.B - nl.A
+	return SecondDerivative(nl.F, nl.u(t)) / (w * w)
+}
+
+func (nl *NLWindow) Integral(a, b float64) float64 {
+	w := nl.B - nl.A
+	lo, hi := math.Max(a, nl.A), math.Min(b, nl.B)
+	s := 0.0
+	if a < nl.A {
+		s += (math.Min(b, nl.A) - a) * nl.F.Transform(0)
+	}
+	if lo < hi {
+		s += w * Integral(nl.F, nl.u(lo), nl.u(hi))
+	}
+	if b > nl.B {
+		s += (b - math.Max(a, nl.B)) * nl.F.Transform(1)
+	}
+	return s
+}
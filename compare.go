@@ -0,0 +1,63 @@
+package nonlinear
+
+import "math"
+
+// MaxDifference samples f and g at samples+1 evenly spaced points in [0,1] and returns the largest
+// |f(t) - g(t)| and the t at which it occurs. NaN in either curve counts as an infinite difference.
+func MaxDifference(f, g NonLinear, samples int) (float64, float64) {
+	if samples < 1 {
+		samples = 1
+	}
+	md, mt := 0.0, 0.0
+	for i := 0; i <= samples; i++ {
+		t := float64(i) / float64(samples)
+		d := math.Abs(f.Transform(t) - g.Transform(t))
+		if math.IsNaN(d) {
+			d = math.Inf(1)
+		}
+		if d > md {
+			md, mt = d, t
+		}
+	}
+	return md, mt
+}
+
+// ApproxEqual returns true if f and g differ by no more than eps at samples+1 evenly spaced points
+// in [0,1].
+func ApproxEqual(f, g NonLinear, eps float64, samples int) bool {
+	d, _ := MaxDifference(f, g, samples)
+	return d <= eps
+}
+
+// Comparison holds the measures of the difference between two curves returned by Compare.
+type Comparison struct {
+	MaxDeviation   float64 // Largest |f(t) - g(t)|
+	MaxDeviationT  float64 // The t at which it occurs
+	L2             float64 // Square root of the integral of (f(t) - g(t))^2 over [0,1]
+	AreaDifference float64 // Integral of f less that of g over [0,1]
+}
+
+// Compare measures how f and g differ over [0,1]. The maximum deviation is sampled as by
+// MaxDifference and the L2 distance integrated with Simpson's rule, both at 4096 intervals, and the
+// areas found with Integral.
+func Compare(f, g NonLinear) Comparison {
+	const n = 4096
+	var c Comparison
+	c.MaxDeviation, c.MaxDeviationT = MaxDifference(f, g, n)
+	sum := 0.0
+	for i := 0; i <= n; i++ {
+		t := float64(i) / n
+		d := f.Transform(t) - g.Transform(t)
+		w := 2.0
+		switch {
+		case i == 0 || i == n:
+			w = 1
+		case i%2 == 1:
+			w = 4
+		}
+		sum += w * d * d
+	}
+	c.L2 = math.Sqrt(sum / (3 * n))
+	c.AreaDifference = Integral(f, 0, 1) - Integral(g, 0, 1)
+	return c
+}
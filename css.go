@@ -0,0 +1,187 @@
+package nonlinear
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ParseCSSEasing converts a CSS <easing-function> into a curve. The keywords linear, ease, ease-in,
+// ease-out, ease-in-out, step-start and step-end are supported, as are the functions cubic-bezier(),
+// steps() and linear().
+func ParseCSSEasing(s string) (NonLinear, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "linear":
+		return &NLLinear{}, nil
+	case "ease":
+		return NewNLCubicBezier(0.25, 0.1, 0.25, 1), nil
+	case "ease-in":
+		return NewNLCubicBezier(0.42, 0, 1, 1), nil
+	case "ease-out":
+		return NewNLCubicBezier(0, 0, 0.58, 1), nil
+	case "ease-in-out":
+		return NewNLCubicBezier(0.42, 0, 0.58, 1), nil
+	case "step-start":
+		return NewNLSteps(1, JumpStart), nil
+	case "step-end":
+		return NewNLSteps(1, JumpEnd), nil
+	}
+
+	open := strings.IndexByte(s, '(')
+	if open < 0 || !strings.HasSuffix(s, ")") {
+		return nil, fmt.Errorf("nonlinear: unknown CSS easing %q", s)
+	}
+	name := strings.TrimSpace(s[:open])
+	args := strings.Split(s[open+1:len(s)-1], ",")
+	for i := range args {
+		args[i] = strings.TrimSpace(args[i])
+	}
+
+	switch name {
+	case "cubic-bezier":
+		if len(args) != 4 {
+			return nil, fmt.Errorf("nonlinear: cubic-bezier() takes 4 arguments, got %d", len(args))
+		}
+		p := make([]float64, 4)
+		for i, a := range args {
+			v, err := strconv.ParseFloat(a, 64)
+			if err != nil {
+				return nil, fmt.Errorf("nonlinear: bad cubic-bezier() argument %q", a)
+			}
+			p[i] = v
+		}
+		return NewWith("cubicbezier", p)
+	case "steps":
+		if len(args) < 1 || len(args) > 2 {
+			return nil, fmt.Errorf("nonlinear: steps() takes 1 or 2 arguments, got %d", len(args))
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("nonlinear: bad steps() count %q", args[0])
+		}
+		pos := JumpEnd
+		if len(args) == 2 {
+			switch args[1] {
+			case "jump-start", "start":
+				pos = JumpStart
+			case "jump-end", "end":
+				pos = JumpEnd
+			case "jump-none":
+				pos = JumpNone
+			case "jump-both":
+				pos = JumpBoth
+			default:
+				return nil, fmt.Errorf("nonlinear: bad steps() position %q", args[1])
+			}
+		}
+		return NewWith("steps", []float64{float64(n), float64(pos)})
+	case "linear":
+		return cssLinear(args)
+	}
+	return nil, fmt.Errorf("nonlinear: unknown CSS easing function %q", name)
+}
+
+// cssLinear implements the CSS linear() easing function, returning an NLStopped. As in CSS, the first
+// and last segments are extended beyond the first and last stops. Stops outside of [0,1] are replaced
+// by ones at 0 and 1, which only changes the curve outside of [0,1].
+func cssLinear(args []string) (NonLinear, error) {
+	var vals, pos []float64
+	for _, a := range args {
+		fields := strings.Fields(a)
+		if len(fields) < 1 || len(fields) > 3 {
+			return nil, fmt.Errorf("nonlinear: bad linear() stop %q", a)
+		}
+		v, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("nonlinear: bad linear() value %q", fields[0])
+		}
+		if len(fields) == 1 {
+			vals = append(vals, v)
+			pos = append(pos, math.NaN())
+			continue
+		}
+		for _, f := range fields[1:] {
+			p, err := strconv.ParseFloat(strings.TrimSuffix(f, "%"), 64)
+			if err != nil || !strings.HasSuffix(f, "%") {
+				return nil, fmt.Errorf("nonlinear: bad linear() position %q", f)
+			}
+			vals = append(vals, v)
+			pos = append(pos, p/100)
+		}
+	}
+	n := len(vals)
+	if n < 2 {
+		return nil, fmt.Errorf("nonlinear: linear() needs at least 2 stops")
+	}
+
+	// Default the end positions, force positions to be non-decreasing and then spread
+	// missing positions evenly between their neighbors
+	if math.IsNaN(pos[0]) {
+		pos[0] = 0
+	}
+	if math.IsNaN(pos[n-1]) {
+		pos[n-1] = math.Max(1, pos[0])
+	}
+	max := pos[0]
+	for i := 1; i < n; i++ {
+		if !math.IsNaN(pos[i]) {
+			if pos[i] < max {
+				pos[i] = max
+			}
+			max = pos[i]
+		}
+	}
+	for i := 1; i < n; i++ {
+		if !math.IsNaN(pos[i]) {
+			continue
+		}
+		j := i + 1
+		for math.IsNaN(pos[j]) {
+			j++
+		}
+		p0, p1 := pos[i-1], pos[j]
+		for k := i; k < j; k++ {
+			pos[k] = p0 + (p1-p0)*float64(k-i+1)/float64(j-i+1)
+		}
+	}
+
+	// CSS extends the first and last segments rather than adding stops at (0,0) and (1,1), so the
+	// stops are clipped to [0,1], with stops added at 0 and 1 on the extended segments as needed
+	at := func(t float64) float64 {
+		i := 0
+		switch {
+		case t < pos[0]:
+			i = 0
+		case t >= pos[n-1]:
+			i = n - 2
+		default:
+			for pos[i+1] <= t {
+				i++
+			}
+		}
+		if pos[i+1] == pos[i] {
+			// A jump at an end is extended flat
+			if t < pos[i] {
+				return vals[i]
+			}
+			return vals[i+1]
+		}
+		return vals[i] + (t-pos[i])*(vals[i+1]-vals[i])/(pos[i+1]-pos[i])
+	}
+	stops := make([][]float64, 0, n+2)
+	if !slices.Contains(pos, 0) {
+		stops = append(stops, []float64{0, at(0)})
+	}
+	for i, p := range pos {
+		if p >= 0 && p <= 1 {
+			stops = append(stops, []float64{p, vals[i]})
+		}
+	}
+	if !slices.Contains(pos, 1) {
+		stops = append(stops, []float64{1, at(1)})
+	}
+	return NewNLStopped(stops), nil
+}
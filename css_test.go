@@ -0,0 +1,74 @@
+package nonlinear
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCSSLinear(t *testing.T) {
+	tests := []struct {
+		css  string
+		t, v float64
+	}{
+		{"linear(0, 0.25, 1)", 0.25, 0.125},
+		{"linear(0, 0.25, 1)", 0.75, 0.625},
+		{"linear(0, 0.25 75%, 1)", 0.5, 1.0 / 6},
+		{"linear(0, 0.25 75%, 1)", 0.875, 0.625},
+		{"linear(0, 0.25 25% 75%, 1)", 0.5, 0.25},
+		{"linear(0.5 20%, 1)", 0.1, 0.4375},
+		{"linear(0.5 20%, 1)", 0, 0.375},
+		{"linear(0, 1 50%)", 0.75, 1.5},
+		{"linear(0, 1 50%)", 1, 2},
+		{"linear(0, 1)", 1.5, 1.5},
+		{"linear(0, 1)", -0.5, -0.5},
+		{"linear(0.2 20% 20%, 1)", 0.1, 0.2},
+		{"linear(0, 0.6 80% 80%)", 0.9, 0.6},
+		{"linear(0 -10%, 1 110%)", 0, 1.0 / 12},
+		{"linear(0 -10%, 1 110%)", 0.5, 0.5},
+		{"linear(0, 0.5 120%, 1)", 0.6, 0.25},
+		{"linear(0, 0.5 120%, 1)", 1, 5.0 / 12},
+		{"linear(0 -50%, 0.25 -20%, 0.5 10%, 1 50%)", 0.3, 0.75},
+		{"linear(0 -50%, 0.25 -20%, 0.5 10%, 1 50%)", 0, 5.0 / 12},
+	}
+	for _, tt := range tests {
+		f, err := ParseCSSEasing(tt.css)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.css, err)
+		}
+		if v := f.Transform(tt.t); math.Abs(v-tt.v) > 1e-12 {
+			t.Errorf("%s at %g = %g, want %g", tt.css, tt.t, v, tt.v)
+		}
+	}
+}
+
+func TestCSSLinearRoundTrip(t *testing.T) {
+	for _, css := range []string{"linear(0 -10%, 1 110%)", "linear(0, 0.5 120%, 1)", "linear(0.5 20%, 1)", "linear(0, 0.25 25% 75%, 1)"} {
+		f, err := ParseCSSEasing(css)
+		if err != nil {
+			t.Fatalf("%s: %v", css, err)
+		}
+		b, err := MarshalCurve(f)
+		if err != nil {
+			t.Fatalf("%s: %v", css, err)
+		}
+		g, err := UnmarshalCurve(b)
+		if err != nil {
+			t.Errorf("%s: UnmarshalCurve(%s): %v", css, b, err)
+			continue
+		}
+		h, err := Parse(FormatCurve(f))
+		if err != nil {
+			t.Errorf("%s: Parse(%s): %v", css, FormatCurve(f), err)
+			continue
+		}
+		for i := 0; i <= 10; i++ {
+			x := float64(i) / 10
+			if v, w := g.Transform(x), f.Transform(x); v != w {
+				t.Errorf("%s: JSON round trip at %g = %g, want %g", css, x, v, w)
+			}
+			if v, w := h.Transform(x), f.Transform(x); v != w {
+				t.Errorf("%s: text round trip at %g = %g, want %g", css, x, v, w)
+			}
+		}
+	}
+}
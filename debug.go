@@ -0,0 +1,92 @@
+package nonlinear
+
+import (
+	"fmt"
+	"math"
+)
+
+// ViolationKind identifies the check failed by a call to an NLChecked curve.
+type ViolationKind int
+
+const (
+	InputOutOfRange  ViolationKind = iota // The argument isn't in [0,1]
+	OutputNotFinite                       // The result is NaN or infinite
+	OutputOutOfRange                      // The result of Transform or InvTransform isn't in [0,1]
+)
+
+func (k ViolationKind) String() string {
+	switch k {
+	case InputOutOfRange:
+		return "input out of range"
+	case OutputNotFinite:
+		return "output not finite"
+	case OutputOutOfRange:
+		return "output out of range"
+	}
+	return fmt.Sprintf("ViolationKind(%d)", int(k))
+}
+
+// Violation describes a failed check. For InputOutOfRange, Out is NaN as f hasn't been called yet.
+type Violation struct {
+	Kind    ViolationKind
+	F       NonLinear
+	Method  string // Transform, InvTransform, Derivative or SecondDerivative
+	In, Out float64
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("nonlinear: %s(%g) = %g: %s", v.Method, v.In, v.Out, v.Kind)
+}
+
+// NLChecked wraps f, for use during development, checking that arguments are in [0,1] and that
+// results are finite and, for Transform and InvTransform, in [0,1]. Each failed check is passed to
+// OnViolation, after which the call continues, or if OnViolation is nil, the call panics with the
+// Violation. Curves that overshoot, such as NLBack, will report OutputOutOfRange violations which
+// OnViolation can choose to ignore.
+type NLChecked struct {
+	F           NonLinear
+	OnViolation func(Violation)
+}
+
+func NewNLChecked(f NonLinear, onViolation func(Violation)) *NLChecked {
+	return &NLChecked{f, onViolation}
+}
+
+func (nl *NLChecked) violation(kind ViolationKind, method string, in, out float64) {
+	v := Violation{kind, nl.F, method, in, out}
+	if nl.OnViolation == nil {
+		panic(v)
+	}
+	nl.OnViolation(v)
+}
+
+// check calls fn on x, reporting any violations. ranged selects whether the result must be in [0,1].
+func (nl *NLChecked) check(method string, fn func(float64) float64, x float64, ranged bool) float64 {
+	if !(x >= 0 && x <= 1) {
+		nl.violation(InputOutOfRange, method, x, math.NaN())
+	}
+	r := fn(x)
+	switch {
+	case !isFinite(r):
+		nl.violation(OutputNotFinite, method, x, r)
+	case ranged && !(r >= 0 && r <= 1):
+		nl.violation(OutputOutOfRange, method, x, r)
+	}
+	return r
+}
+
+func (nl *NLChecked) Transform(t float64) float64 {
+	return nl.check("Transform", nl.F.Transform, t, true)
+}
+
+func (nl *NLChecked) InvTransform(v float64) float64 {
+	return nl.check("InvTransform", nl.F.InvTransform, v, true)
+}
+
+func (nl *NLChecked) Derivative(t float64) float64 {
+	return nl.check("Derivative", func(t float64) float64 { return Derivative(nl.F, t) }, t, false)
+}
+
+func (nl *NLChecked) SecondDerivative(t float64) float64 {
+	return nl.check("SecondDerivative", func(t float64) float64 { return SecondDerivative(nl.F, t) }, t, false)
+}
@@ -0,0 +1,56 @@
+package nonlinear
+
+import "math"
+
+// Derivative returns dv/dt of f at t, using the analytic derivative if f implements NonLinearD
+// and FiniteDerivative otherwise.
+func Derivative(f NonLinear, t float64) float64 {
+	if fd, ok := f.(NonLinearD); ok {
+		return fd.Derivative(t)
+	}
+	return FiniteDerivative(f, t)
+}
+
+// FiniteDerivative approximates dv/dt of f at t using central differences. One sided differences
+// are used near 0 and 1 to avoid evaluating f outside of [0,1].
+func FiniteDerivative(f NonLinear, t float64) float64 {
+	h := 1e-6
+	t0, t1 := t-h, t+h
+	if t0 < 0 {
+		t0 = t
+	}
+	if t1 > 1 {
+		t1 = t
+	}
+	return (f.Transform(t1) - f.Transform(t0)) / (t1 - t0)
+}
+
+// SecondDerivative returns d2v/dt2 of f at t, using the analytic second derivative if f implements
+// NonLinearD2 and FiniteSecondDerivative otherwise.
+func SecondDerivative(f NonLinear, t float64) float64 {
+	if fd, ok := f.(NonLinearD2); ok {
+		return fd.SecondDerivative(t)
+	}
+	return FiniteSecondDerivative(f, t)
+}
+
+// FiniteSecondDerivative approximates d2v/dt2 of f at t by differencing Derivative. As with
+// FiniteDerivative, one sided differences are used near 0 and 1.
+func FiniteSecondDerivative(f NonLinear, t float64) float64 {
+	h := 1e-4
+	t0, t1 := t-h, t+h
+	if t0 < 0 {
+		t0 = t
+	}
+	if t1 > 1 {
+		t1 = t
+	}
+	return (Derivative(f, t1) - Derivative(f, t0)) / (t1 - t0)
+}
+
+// Curvature returns the signed curvature of the graph of f at t, d2v/dt2 / (1 + (dv/dt)^2)^(3/2).
+func Curvature(f NonLinear, t float64) float64 {
+	d1 := Derivative(f, t)
+	d2 := SecondDerivative(f, t)
+	return d2 / math.Pow(1+d1*d1, 1.5)
+}
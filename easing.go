@@ -0,0 +1,219 @@
+package nonlinear
+
+import (
+	"math"
+	"strings"
+)
+
+// NLPower v = t^p
+type NLPower struct {
+	P    float64
+	Fast bool // Use an approximation of pow, see NewNLPowerFast
+}
+
+func NewNLPower(p float64) *NLPower {
+	return &NLPower{P: p}
+}
+
+func (nl *NLPower) Transform(t float64) float64 {
+	if nl.Fast {
+		return fastPow(t, nl.P)
+	}
+	return math.Pow(t, nl.P)
+}
+
+func (nl *NLPower) InvTransform(v float64) float64 {
+	return math.Pow(v, 1/nl.P)
+}
+
+func (nl *NLPower) Derivative(t float64) float64 {
+	return nl.P * math.Pow(t, nl.P-1)
+}
+
+func (nl *NLPower) SecondDerivative(t float64) float64 {
+	return nl.P * (nl.P - 1) * math.Pow(t, nl.P-2)
+}
+
+func (nl *NLPower) Integral(a, b float64) float64 {
+	q := nl.P + 1
+	return (math.Pow(b, q) - math.Pow(a, q)) / q
+}
+
+// NLBack v = t^2 * ((c+1)t - c), which dips below 0 before rising to 1. Penner uses c = 1.70158.
+// Not monotonic - InvTransform returns the t in [c/(c+1),1] for v in [0,1], InvTransformAll every t.
+type NLBack struct {
+	C float64
+}
+
+func NewNLBack(c float64) *NLBack {
+	return &NLBack{c}
+}
+
+func (nl *NLBack) Transform(t float64) float64 {
+	return t * t * ((nl.C+1)*t - nl.C)
+}
+
+func (nl *NLBack) InvTransform(v float64) float64 {
+	return bsInv(v, nl)
+}
+
+func (nl *NLBack) Derivative(t float64) float64 {
+	return t * (3*(nl.C+1)*t - 2*nl.C)
+}
+
+func (nl *NLBack) SecondDerivative(t float64) float64 {
+	return 6*(nl.C+1)*t - 2*nl.C
+}
+
+func (nl *NLBack) Integral(a, b float64) float64 {
+	return (nl.C+1)*(b*b*b*b-a*a*a*a)/4 - nl.C*(b*b*b-a*a*a)/3
+}
+
+// NLElastic v = -2^(10(t-1)) * sin((t-1-p/4) * 2Pi/p), an exponentially growing oscillation with
+// period p, with f(0) defined as 0. Penner uses p = 0.3. Not monotonic - InvTransform returns one of
+// the t for which f(t) = v, found numerically, and InvTransformAll all of them.
+type NLElastic struct {
+	P float64
+}
+
+func NewNLElastic(p float64) *NLElastic {
+	return &NLElastic{p}
+}
+
+func (nl *NLElastic) Transform(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	u := t - 1
+	return -math.Exp2(10*u) * math.Sin((u-nl.P/4)*2*math.Pi/nl.P)
+}
+
+func (nl *NLElastic) InvTransform(v float64) float64 {
+	return bsInv(v, nl)
+}
+
+func (nl *NLElastic) Derivative(t float64) float64 {
+	u, k, w := t-1, 10*math.Ln2, 2*math.Pi/nl.P
+	s, c := math.Sincos((u - nl.P/4) * w)
+	return -math.Exp2(10*u) * (k*s + w*c)
+}
+
+func (nl *NLElastic) SecondDerivative(t float64) float64 {
+	u, k, w := t-1, 10*math.Ln2, 2*math.Pi/nl.P
+	s, c := math.Sincos((u - nl.P/4) * w)
+	return -math.Exp2(10*u) * ((k*k-w*w)*s + 2*k*w*c)
+}
+
+// NLBounce v = 1 - bounce(1-t) where bounce is Penner's easeOutBounce, four parabolic arcs of
+// decreasing height. Not monotonic - InvTransform returns one of the t for which f(t) = v, found
+// numerically, and InvTransformAll all of them.
+type NLBounce struct{}
+
+func (nl *NLBounce) Transform(t float64) float64 {
+	v, _ := bounceOut(1 - t)
+	return 1 - v
+}
+
+func (nl *NLBounce) InvTransform(v float64) float64 {
+	return bsInv(v, nl)
+}
+
+func (nl *NLBounce) Derivative(t float64) float64 {
+	_, d := bounceOut(1 - t)
+	return d
+}
+
+func (nl *NLBounce) SecondDerivative(t float64) float64 {
+	return -2 * bounceN
+}
+
+const (
+	bounceN = 7.5625
+	bounceD = 2.75
+)
+
+// bounceOut returns Penner's easeOutBounce and its derivative at x.
+func bounceOut(x float64) (float64, float64) {
+	var c, h float64
+	switch {
+	case x < 1/bounceD:
+		c, h = 0, 0
+	case x < 2/bounceD:
+		c, h = 1.5/bounceD, 0.75
+	case x < 2.5/bounceD:
+		c, h = 2.25/bounceD, 0.9375
+	default:
+		c, h = 2.625/bounceD, 0.984375
+	}
+	x -= c
+	return bounceN*x*x + h, 2 * bounceN * x
+}
+
+// Constants used by Penner's back easings.
+const (
+	backC      = 1.70158
+	backInOutC = backC * 1.525
+)
+
+// easeInOut joins in and its mirror image at t = 0.5.
+func easeInOut(in NonLinear) NonLinear {
+	return NewNLInOut(in, NewNLOmt(in), 0.5)
+}
+
+// The Penner easings, named as in easings.net. The In forms accelerate from rest, the Out forms
+// decelerate to rest, and the InOut forms do both, meeting at (0.5, 0.5). The Expo forms are
+// normalized to pass exactly through 0 and 1.
+
+func EaseInQuad() NonLinear       { return &NLSquare{} }
+func EaseOutQuad() NonLinear      { return NewNLOmt(&NLSquare{}) }
+func EaseInOutQuad() NonLinear    { return easeInOut(&NLSquare{}) }
+func EaseInCubic() NonLinear      { return &NLCube{} }
+func EaseOutCubic() NonLinear     { return NewNLOmt(&NLCube{}) }
+func EaseInOutCubic() NonLinear   { return easeInOut(&NLCube{}) }
+func EaseInQuart() NonLinear      { return NewNLPower(4) }
+func EaseOutQuart() NonLinear     { return NewNLOmt(NewNLPower(4)) }
+func EaseInOutQuart() NonLinear   { return easeInOut(NewNLPower(4)) }
+func EaseInQuint() NonLinear      { return NewNLPower(5) }
+func EaseOutQuint() NonLinear     { return NewNLOmt(NewNLPower(5)) }
+func EaseInOutQuint() NonLinear   { return easeInOut(NewNLPower(5)) }
+func EaseInSine() NonLinear       { return &NLSin2{} }
+func EaseOutSine() NonLinear      { return &NLSin1{} }
+func EaseInOutSine() NonLinear    { return &NLSin{} }
+func EaseInExpo() NonLinear       { return NewNLExponential(10 * math.Ln2) }
+func EaseOutExpo() NonLinear      { return NewNLOmt(NewNLExponential(10 * math.Ln2)) }
+func EaseInOutExpo() NonLinear    { return easeInOut(NewNLExponential(10 * math.Ln2)) }
+func EaseInCirc() NonLinear       { return &NLCircle1{} }
+func EaseOutCirc() NonLinear      { return &NLCircle2{} }
+func EaseInOutCirc() NonLinear    { return NewNLInOut(&NLCircle1{}, &NLCircle2{}, 0.5) }
+func EaseInBack() NonLinear       { return NewNLBack(backC) }
+func EaseOutBack() NonLinear      { return NewNLOmt(NewNLBack(backC)) }
+func EaseInOutBack() NonLinear    { return easeInOut(NewNLBack(backInOutC)) }
+func EaseInElastic() NonLinear    { return NewNLElastic(0.3) }
+func EaseOutElastic() NonLinear   { return NewNLOmt(NewNLElastic(0.3)) }
+func EaseInOutElastic() NonLinear { return easeInOut(NewNLElastic(0.45)) }
+func EaseInBounce() NonLinear     { return &NLBounce{} }
+func EaseOutBounce() NonLinear    { return NewNLOmt(&NLBounce{}) }
+func EaseInOutBounce() NonLinear  { return easeInOut(&NLBounce{}) }
+
+// penner lists the Penner easings for registration, by lower cased name since Parse folds case.
+var penner = []struct {
+	name string
+	fn   func() NonLinear
+}{
+	{"easeInQuad", EaseInQuad}, {"easeOutQuad", EaseOutQuad}, {"easeInOutQuad", EaseInOutQuad},
+	{"easeInCubic", EaseInCubic}, {"easeOutCubic", EaseOutCubic}, {"easeInOutCubic", EaseInOutCubic},
+	{"easeInQuart", EaseInQuart}, {"easeOutQuart", EaseOutQuart}, {"easeInOutQuart", EaseInOutQuart},
+	{"easeInQuint", EaseInQuint}, {"easeOutQuint", EaseOutQuint}, {"easeInOutQuint", EaseInOutQuint},
+	{"easeInSine", EaseInSine}, {"easeOutSine", EaseOutSine}, {"easeInOutSine", EaseInOutSine},
+	{"easeInExpo", EaseInExpo}, {"easeOutExpo", EaseOutExpo}, {"easeInOutExpo", EaseInOutExpo},
+	{"easeInCirc", EaseInCirc}, {"easeOutCirc", EaseOutCirc}, {"easeInOutCirc", EaseInOutCirc},
+	{"easeInBack", EaseInBack}, {"easeOutBack", EaseOutBack}, {"easeInOutBack", EaseInOutBack},
+	{"easeInElastic", EaseInElastic}, {"easeOutElastic", EaseOutElastic}, {"easeInOutElastic", EaseInOutElastic},
+	{"easeInBounce", EaseInBounce}, {"easeOutBounce", EaseOutBounce}, {"easeInOutBounce", EaseInOutBounce},
+}
+
+func init() {
+	for _, p := range penner {
+		simple(strings.ToLower(p.name), "Penner "+p.name, p.fn)
+	}
+}
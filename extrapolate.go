@@ -0,0 +1,136 @@
+package nonlinear
+
+import "math"
+
+// Extrapolation determines how t outside of [0,1] is handled by NLerpEx and its relatives.
+type Extrapolation int
+
+const (
+	Clamp  Extrapolation = iota // t is clamped to [0,1], as for NLerp
+	Extend                      // The curve is continued along its tangent at the nearest end
+	Wrap                        // t is taken modulo 1, for repeating patterns, with whole t > 0 taken to 1
+	Mirror                      // t runs back and forth across [0,1], for patterns that reflect
+)
+
+// reduce maps t into [0,1] according to mode, leaving t in [0,1] as it is. Extend is treated as Clamp.
+func (mode Extrapolation) reduce(t float64) float64 {
+	if t >= 0 && t <= 1 {
+		return t
+	}
+	switch mode {
+	case Wrap:
+		// Each period after 1 ends at 1, as the first does
+		if t > 1 {
+			return t + 1 - math.Ceil(t)
+		}
+		return t - math.Floor(t)
+	case Mirror:
+		t = math.Abs(t - 2*math.Floor(t/2)) // [0,2)
+		if t > 1 {
+			t = 2 - t
+		}
+		return t
+	}
+	return math.Min(1, math.Max(0, t))
+}
+
+// TransformEx returns f(t) with t outside of [0,1] handled according to mode.
+func TransformEx(f NonLinear, t float64, mode Extrapolation) float64 {
+	if mode == Extend {
+		if t < 0 {
+			return f.Transform(0) + t*Derivative(f, 0)
+		}
+		if t > 1 {
+			return f.Transform(1) + (t-1)*Derivative(f, 1)
+		}
+	}
+	return f.Transform(mode.reduce(t))
+}
+
+// InvTransformEx returns the inverse of f at v with v outside of [0,1] handled according to mode. For
+// Wrap and Mirror the result is always in [0,1]; for Extend it is the inverse of TransformEx, and
+// infinite if f is flat at the end concerned.
+func InvTransformEx(f NonLinear, v float64, mode Extrapolation) float64 {
+	if mode == Extend {
+		if v < 0 {
+			return v / Derivative(f, 0)
+		}
+		if v > 1 {
+			return 1 + (v-1)/Derivative(f, 1)
+		}
+	}
+	return f.InvTransform(mode.reduce(v))
+}
+
+// NLerpEx is NLerp with t outside of [0,1] handled according to mode.
+func NLerpEx(t, start, end float64, f NonLinear, mode Extrapolation) float64 {
+	t = TransformEx(f, t, mode)
+	return (1-t)*start + t*end
+}
+
+// InvNLerpEx is InvNLerp with v outside of [start, end] handled according to mode. Zero width ranges
+// are handled as for InvNLerp.
+func InvNLerpEx(v, start, end float64, f NonLinear, mode Extrapolation) float64 {
+	if start == end {
+		return InvNLerp(v, start, end, f)
+	}
+	return InvTransformEx(f, (v-start)/(end-start), mode)
+}
+
+// RemapNLEx is RemapNL with values outside of the ranges handled according to mode.
+func RemapNLEx(v, istart, iend, ostart, oend float64, fi, fo NonLinear, mode Extrapolation) float64 {
+	return NLerpEx(InvNLerpEx(v, istart, iend, fi, mode), ostart, oend, fo, mode)
+}
+
+// NLDomain wraps f, handling t outside of [0,1] according to Mode so that inputs that have drifted
+// slightly out of range, from accumulated rounding for example, don't take curves such as NLCircle1
+// or NLLame to NaN. To catch such inputs instead, use NLChecked.
+type NLDomain struct {
+	F    NonLinear
+	Mode Extrapolation
+}
+
+func NewNLDomain(f NonLinear, mode Extrapolation) *NLDomain {
+	return &NLDomain{f, mode}
+}
+
+func (nl *NLDomain) Transform(t float64) float64 {
+	if t >= 0 && t <= 1 {
+		return nl.F.Transform(t)
+	}
+	return TransformEx(nl.F, t, nl.Mode)
+}
+
+func (nl *NLDomain) InvTransform(v float64) float64 {
+	return InvTransformEx(nl.F, v, nl.Mode)
+}
+
+func (nl *NLDomain) Derivative(t float64) float64 {
+	if t >= 0 && t <= 1 {
+		return Derivative(nl.F, t)
+	}
+	switch nl.Mode {
+	case Extend:
+		return Derivative(nl.F, math.Min(1, math.Max(0, t)))
+	case Wrap:
+		return Derivative(nl.F, Wrap.reduce(t))
+	case Mirror:
+		// Odd passes across [0,1] run backwards
+		if u := t - 2*math.Floor(t/2); u > 1 {
+			return -Derivative(nl.F, 2-u)
+		}
+		return Derivative(nl.F, Mirror.reduce(t))
+	}
+	return 0
+}
+
+func (nl *NLDomain) SecondDerivative(t float64) float64 {
+	if t >= 0 && t <= 1 {
+		return SecondDerivative(nl.F, t)
+	}
+	switch nl.Mode {
+	case Wrap, Mirror:
+		return SecondDerivative(nl.F, nl.Mode.reduce(t))
+	}
+	return 0
+}
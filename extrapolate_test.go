@@ -0,0 +1,48 @@
+package nonlinear
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTransformEx(t *testing.T) {
+	f := &NLLinear{}
+	tests := []struct {
+		mode Extrapolation
+		t, v float64
+	}{
+		{Clamp, -0.5, 0},
+		{Clamp, 1.5, 1},
+		{Extend, 1.5, 1.5},
+		{Wrap, 0, 0},
+		{Wrap, 1, 1},
+		{Wrap, 1.25, 0.25},
+		{Wrap, 2, 1},
+		{Wrap, -0.25, 0.75},
+		{Wrap, -1, 0},
+		{Mirror, 1, 1},
+		{Mirror, 1.25, 0.75},
+		{Mirror, 2, 0},
+		{Mirror, -0.25, 0.25},
+	}
+	for _, tt := range tests {
+		if v := TransformEx(f, tt.t, tt.mode); math.Abs(v-tt.v) > 1e-12 {
+			t.Errorf("TransformEx(%g, %d) = %g, want %g", tt.t, tt.mode, v, tt.v)
+		}
+	}
+	if v := NLerpEx(1, 0, 10, f, Wrap); v != 10 {
+		t.Errorf("NLerpEx(1, 0, 10, Wrap) = %g, want 10", v)
+	}
+}
+
+func TestNLDomain(t *testing.T) {
+	f := NewNLPower(2)
+	for _, mode := range []Extrapolation{Clamp, Extend, Wrap, Mirror} {
+		d := NewNLDomain(f, mode)
+		for _, x := range []float64{0, 0.5, 1} {
+			if v, want := d.Transform(x), f.Transform(x); v != want {
+				t.Errorf("mode %d: Transform(%g) = %g, want %g", mode, x, v, want)
+			}
+		}
+	}
+}
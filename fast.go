@@ -0,0 +1,154 @@
+package nonlinear
+
+import "math"
+
+// The Fast variants trade accuracy in Transform for speed by replacing the standard library's exp,
+// pow and sin with truncated Taylor series. These aren't minimax fits, so the error is least at the
+// center of the reduced range and greatest at its ends, where the bounds stated below are reached.
+// InvTransform and the derivatives are unaffected. Fast curves are registered, and so serialized,
+// under the name of the curve with "_fast" appended, e.g. "exponential_fast", so they stay Fast when
+// sent to another machine.
+//
+// The Fast Transforms are also deterministic: the approximations use only +, -, * and / with explicit
+// conversions preventing fused multiply-adds, so they produce bit-identical results on every
+// architecture, unlike math.Exp and math.Pow which have assembly or FMA-using implementations on some.
+// The Fast constructors compute their scale factors with the same approximations, so this extends to
+// the curve as a whole, as needed for lockstep simulations and reproducible renders.
+//
+// Only NLExponential, NLGauss, NLLogistic, NLSin and NLPower have Fast variants. The other curves
+// using the standard library, such as NLLame, NLCatenary, NLLogarithmic, NLSin1 and NLSin2, and any
+// combinator wrapping them, aren't guaranteed to be bit-identical across architectures. Curves built
+// only from arithmetic, such as NLP3, NLCubicBezier, NLStopped and NLPolynomial, are, provided the
+// compiler doesn't fuse their multiply-adds.
+
+// fastName returns the registry name of a curve, with "_fast" appended if fast.
+func fastName(name string, fast bool) string {
+	if fast {
+		return name + "_fast"
+	}
+	return name
+}
+
+// NewNLExponentialFast is NewNLExponential using an approximation of exp with a relative error below
+// 6e-5.
+func NewNLExponentialFast(k float64) *NLExponential {
+	nl := NewNLExponential(k)
+	nl.setFast()
+	return nl
+}
+
+// NewNLGaussFast is NewNLGauss using an approximation of exp with a relative error below 6e-5.
+func NewNLGaussFast(k float64) *NLGauss {
+	nl := NewNLGauss(k)
+	nl.setFast()
+	return nl
+}
+
+// NewNLLogisticFast is NewNLLogistic using an approximation of exp with a relative error below 6e-5.
+func NewNLLogisticFast(k, mp float64) *NLLogistic {
+	nl := NewNLLogistic(k, mp)
+	nl.setFast()
+	return nl
+}
+
+// NewNLSinFast returns an NLSin using a polynomial approximation of sin with a maximum error in v of
+// 1e-4 for t in [0,1].
+func NewNLSinFast() *NLSin {
+	return &NLSin{Fast: true}
+}
+
+// NewNLPowerFast is NewNLPower using an approximation of pow with a relative error below 6e-5.
+func NewNLPowerFast(p float64) *NLPower {
+	return &NLPower{P: p, Fast: true}
+}
+
+// setFast sets Fast and recomputes the scale factors with fastExp so that the end points are exact.
+func (nl *NLExponential) setFast() {
+	nl.Fast, nl.Scale = true, 1
+	nl.Scale = 1 / nl.Transform(1)
+}
+
+func (nl *NLGauss) setFast() {
+	nl.Fast, nl.Offs, nl.Scale = true, 0, 1
+	nl.Offs = nl.Transform(0)
+	nl.Scale = 1 / (1 - nl.Offs)
+}
+
+func (nl *NLLogistic) setFast() {
+	nl.Fast, nl.Offs, nl.Scale = true, 0, 1
+	v0, v1 := nl.Transform(0), nl.Transform(1)
+	nl.Offs, nl.Scale = v0, 1/(v1-v0)
+}
+
+// fastExp approximates exp(x) by reducing x to r in [-ln2/2, ln2/2], using the degree 4 Taylor
+// polynomial for exp(r) and scaling by 2^n via the exponent bits directly. The relative error is at
+// most 5.6e-5, at r = -ln2/2.
+func fastExp(x float64) float64 {
+	if x < -708 {
+		return 0
+	}
+	if x > 709 {
+		return math.Inf(1)
+	}
+	k := float64(x*(1/math.Ln2)) + 0.5
+	n := int64(k)
+	if float64(n) > k {
+		n--
+	}
+	r := x - float64(float64(n)*math.Ln2)
+	p := float64(r * (1.0 / 24))
+	p = float64(r * (1.0/6 + p))
+	p = float64(r * (1.0/2 + p))
+	p = float64(r * (1 + p))
+	return float64((1 + p) * math.Float64frombits(uint64(n+1023)<<52))
+}
+
+// fastLog approximates log(x) by reducing x to m*2^e with m in [sqrt(1/2), sqrt(2)) and summing the
+// series 2*atanh((m-1)/(m+1)) to its s^9 term, for a relative error below 1e-8.
+func fastLog(x float64) float64 {
+	switch {
+	case x == 0:
+		return math.Inf(-1)
+	case x < 0 || math.IsNaN(x):
+		return math.NaN()
+	case math.IsInf(x, 1):
+		return x
+	}
+	m, e := math.Frexp(x)
+	if m < math.Sqrt2/2 {
+		m *= 2
+		e--
+	}
+	s := (m - 1) / (m + 1)
+	s2 := float64(s * s)
+	p := float64(s2 * (1.0 / 9))
+	p = float64(s2 * (1.0/7 + p))
+	p = float64(s2 * (1.0/5 + p))
+	p = float64(s2 * (1.0/3 + p))
+	p = float64(2 * s * (1 + p))
+	return float64(float64(e)*math.Ln2) + p
+}
+
+// fastPow approximates x^y as exp(y*log(x)) using fastExp and fastLog.
+func fastPow(x, y float64) float64 {
+	switch {
+	case y == 0 || x == 1:
+		return 1
+	case x == 0:
+		if y < 0 {
+			return math.Inf(1)
+		}
+		return 0
+	}
+	return fastExp(y * fastLog(x))
+}
+
+// fastSin approximates sin(x) for x in [-Pi/2, Pi/2] with the degree 7 Taylor polynomial. The error
+// is at most 1.6e-4, at x = ±Pi/2.
+func fastSin(x float64) float64 {
+	x2 := float64(x * x)
+	p := float64(x2 * (-1.0 / 5040))
+	p = float64(x2 * (1.0/120 + p))
+	p = float64(x2 * (-1.0/6 + p))
+	return float64(x * (1 + p))
+}
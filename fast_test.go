@@ -0,0 +1,64 @@
+package nonlinear
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestFastSerialization(t *testing.T) {
+	curves := []NonLinear{
+		NewNLExponentialFast(3),
+		NewNLGaussFast(2),
+		NewNLLogisticFast(12, 0.5),
+		NewNLSinFast(),
+		NewNLPowerFast(2.5),
+	}
+	for _, f := range curves {
+		want := f.Transform(0.3)
+
+		b, err := json.Marshal(f)
+		if err != nil {
+			t.Fatalf("%T: %v", f, err)
+		}
+		g, err := UnmarshalCurve(b)
+		if err != nil {
+			t.Fatalf("%s: %v", b, err)
+		}
+		if v := g.Transform(0.3); v != want {
+			t.Errorf("%s: JSON round trip gives %g, want %g", b, v, want)
+		}
+
+		s := FormatCurve(f)
+		g, err = Parse(s)
+		if err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+		if v := g.Transform(0.3); v != want {
+			t.Errorf("%s: text round trip gives %g, want %g", s, v, want)
+		}
+
+		if pf, ok := f.(Parameterized); ok {
+			if err := pf.SetParams(pf.Params()...); err != nil {
+				t.Fatalf("%s: %v", s, err)
+			}
+			if v := pf.Transform(0.3); v != want {
+				t.Errorf("%s: SetParams gives %g, want %g", s, v, want)
+			}
+		}
+	}
+}
+
+func TestFastErrorBounds(t *testing.T) {
+	const n = 100000
+	for i := 0; i <= n; i++ {
+		r := -math.Ln2/2 + math.Ln2*float64(i)/n
+		if e := math.Abs(fastExp(r)/math.Exp(r) - 1); e > 5.6e-5 {
+			t.Fatalf("fastExp(%g) has relative error %g, want <= 5.6e-5", r, e)
+		}
+		x := -math.Pi/2 + math.Pi*float64(i)/n
+		if e := math.Abs(fastSin(x) - math.Sin(x)); e > 1.6e-4 {
+			t.Fatalf("fastSin(%g) has error %g, want <= 1.6e-4", x, e)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package nonlinear
+
+import (
+	"fmt"
+	"math"
+)
+
+// FitStops builds a stop curve from measured (x, y) data. The points are repaired as by RepairStops,
+// so are sorted, have duplicate xs merged and y made non-decreasing, and are then normalized so that
+// the first maps to (0,0) and the last to (1,1). The curve is then simplified to the fewest stops,
+// greedily choosing the point furthest from the curve so far, until every point is within maxErr of it
+// in v, or it has maxStops stops, including the two end ones. A maxStops of 0 means no limit.
+// Descending data should be negated first.
+func FitStops(points [][2]float64, maxStops int, maxErr float64) (*NLStopped, error) {
+	if maxStops < 0 || maxStops == 1 {
+		return nil, invalidParam("maxStops", float64(maxStops), "0 or >= 2")
+	}
+	if !(maxErr >= 0) {
+		return nil, invalidParam("maxErr", maxErr, ">= 0")
+	}
+	ss := make([]Stop, len(points))
+	for i, p := range points {
+		ss[i] = Stop{p[0], p[1]}
+	}
+	ss, _ = RepairStops(ss)
+	n := len(ss)
+	if n < 2 {
+		return nil, fmt.Errorf("nonlinear: FitStops needs at least 2 distinct finite points, got %d", n)
+	}
+	x0, dx := ss[0].T, ss[n-1].T-ss[0].T
+	y0, dy := ss[0].V, ss[n-1].V-ss[0].V
+	if dy == 0 {
+		return nil, fmt.Errorf("nonlinear: FitStops data has no range in y")
+	}
+	for i, s := range ss {
+		ss[i] = Stop{(s.T - x0) / dx, (s.V - y0) / dy}
+	}
+	ss[n-1] = Stop{1, 1}
+	return NewNLStoppedStops(simplifyStops(ss, maxStops, maxErr)), nil
+}
+
+// simplifyStops returns the subset of ss, which must be sorted by t, chosen by repeatedly adding the
+// stop furthest in v from the interpolation of those already chosen, starting from the end stops. It
+// stops once all are within maxErr, or maxStops have been chosen if maxStops > 0. This is the
+// Ramer-Douglas-Peucker algorithm in vertical distance, with the splits made worst first.
+func simplifyStops(ss []Stop, maxStops int, maxErr float64) []Stop {
+	n := len(ss)
+	if n < 3 {
+		return append([]Stop(nil), ss...)
+	}
+	// worst returns the index and error of the stop in (a,b) furthest from the chord a-b
+	worst := func(a, b int) (int, float64) {
+		wi, we := -1, -1.0
+		sa, sb := ss[a], ss[b]
+		for i := a + 1; i < b; i++ {
+			s := ss[i]
+			v := sa.V
+			if sb.T != sa.T {
+				v += (sb.V - sa.V) * (s.T - sa.T) / (sb.T - sa.T)
+			}
+			if e := math.Abs(s.V - v); e > we {
+				wi, we = i, e
+			}
+		}
+		return wi, we
+	}
+	type segment struct {
+		a, b, w int
+		e       float64
+	}
+	keep := make([]bool, n)
+	keep[0], keep[n-1] = true, true
+	w, e := worst(0, n-1)
+	segs := []segment{{0, n - 1, w, e}}
+	for k := 2; maxStops == 0 || k < maxStops; k++ {
+		j := 0
+		for i, s := range segs {
+			if s.e > segs[j].e {
+				j = i
+			}
+		}
+		s := segs[j]
+		if s.w < 0 || s.e <= maxErr {
+			break
+		}
+		keep[s.w] = true
+		lw, le := worst(s.a, s.w)
+		rw, re := worst(s.w, s.b)
+		segs[j] = segment{s.a, s.w, lw, le}
+		segs = append(segs, segment{s.w, s.b, rw, re})
+	}
+	res := make([]Stop, 0, len(segs)+1)
+	for i, s := range ss {
+		if keep[i] {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// fitStarts are the starting parameters Fit tries for each family.
+var fitStarts = map[string][][]float64{
+	"cubicbezier": {{0.42, 0, 0.58, 1}, {0.25, 0.25, 0.75, 0.75}, {0.5, 0, 0.5, 1}},
+	"exponential": {{2}, {-2}},
+	"gauss":       {{2}, {5}},
+	"lame":        {{2, 2}, {1, 3}, {3, 1}},
+	"logarithmic": {{2}, {-0.5}},
+	"logistic":    {{6, 0.5}, {15, 0.5}},
+	"power":       {{2}, {0.5}},
+}
+
+// Fit returns the curve of the named family whose parameters best fit the (t, v) points in the least
+// squares sense, along with the root mean square residual. The families are cubicbezier,
+// exponential, gauss, lame, logarithmic, logistic and power. Levenberg-Marquardt optimization is
+// started from a few typical parameter sets and the best result kept; parameters the family's
+// constructor rejects are never stepped to.
+func Fit(family string, points [][2]float64) (NonLinear, float64, error) {
+	starts, ok := fitStarts[family]
+	if !ok {
+		return nil, 0, fmt.Errorf("nonlinear: Fit doesn't support family %q", family)
+	}
+	m := len(points)
+	if m < len(starts[0]) {
+		return nil, 0, fmt.Errorf("nonlinear: Fit %s needs at least %d points, got %d", family, len(starts[0]), m)
+	}
+	for _, p := range points {
+		if !isFinite(p[0]) || !isFinite(p[1]) {
+			return nil, 0, fmt.Errorf("nonlinear: Fit point (%g, %g) isn't finite", p[0], p[1])
+		}
+	}
+
+	res := func(p, r []float64) bool {
+		f, err := NewWith(family, p)
+		if err != nil {
+			return false
+		}
+		for i, pt := range points {
+			r[i] = f.Transform(pt[0]) - pt[1]
+			if !isFinite(r[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	var best []float64
+	bestCost := math.Inf(1)
+	for _, s := range starts {
+		p, c := levenbergMarquardt(res, s, m, 200)
+		if c < bestCost {
+			best, bestCost = p, c
+		}
+	}
+	if best == nil {
+		return nil, 0, fmt.Errorf("nonlinear: Fit %s found no valid parameters", family)
+	}
+	f, err := NewWith(family, best)
+	if err != nil {
+		return nil, 0, err
+	}
+	return f, math.Sqrt(bestCost / float64(m)), nil
+}
+
+// Approximate returns the parameters of the named family, as accepted by Fit, that best match target
+// in the least squares sense over 256 samples, along with the largest difference between the two over
+// 1001 samples. It's for converting a curve for an output format that only supports some families.
+func Approximate(target NonLinear, family string) ([]float64, float64, error) {
+	const n = 256
+	pts := make([][2]float64, n)
+	for i := range pts {
+		t := (float64(i) + 0.5) / n
+		pts[i] = [2]float64{t, target.Transform(t)}
+	}
+	f, _, err := Fit(family, pts)
+	if err != nil {
+		return nil, 0, err
+	}
+	maxErr, _ := MaxDifference(target, f, 1000)
+	return f.(Parameterized).Params(), maxErr, nil
+}
@@ -0,0 +1,91 @@
+package nonlinear
+
+// NonLinear32 is implemented by functions that can be evaluated natively in float32, avoiding
+// conversions in float32 pipelines.
+type NonLinear32 interface {
+	NonLinear
+	Transform32(t float32) float32
+}
+
+// Transform32 returns f(t), using f's Transform32 if it implements NonLinear32.
+func Transform32(f NonLinear, t float32) float32 {
+	if f32, ok := f.(NonLinear32); ok {
+		return f32.Transform32(t)
+	}
+	return float32(f.Transform(float64(t)))
+}
+
+// TransformSlice32 sets dst[i] = f(ts[i]) for each of the ts. dst must be at least as long as ts.
+func TransformSlice32(f NonLinear, ts, dst []float32) {
+	dst = dst[:len(ts)]
+	if f32, ok := f.(NonLinear32); ok {
+		for i, t := range ts {
+			dst[i] = f32.Transform32(t)
+		}
+		return
+	}
+	for i, t := range ts {
+		dst[i] = float32(f.Transform(float64(t)))
+	}
+}
+
+// NLerp32 is NLerp in float32. Note t is clamped to [0,1]
+func NLerp32(t, start, end float32, f NonLinear) float32 {
+	if t < 0 {
+		return start
+	}
+	if t > 1 {
+		return end
+	}
+	t = Transform32(f, t)
+	return (1-t)*start + t*end
+}
+
+// InvNLerp32 is InvNLerp in float32.
+func InvNLerp32(v, start, end float32, f NonLinear) float32 {
+	return InvNLerpT(v, start, end, f)
+}
+
+func (nl *NLLinear) Transform32(t float32) float32 {
+	return t
+}
+
+func (nl *NLSquare) Transform32(t float32) float32 {
+	return t * t
+}
+
+func (nl *NLCube) Transform32(t float32) float32 {
+	return t * t * t
+}
+
+func (nl *NLP3) Transform32(t float32) float32 {
+	return t * t * (3 - 2*t)
+}
+
+func (nl *NLP5) Transform32(t float32) float32 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+func (nl *NLBack) Transform32(t float32) float32 {
+	c := float32(nl.C)
+	return t * t * ((c+1)*t - c)
+}
+
+func (nl *NLCompound) Transform32(t float32) float32 {
+	for _, f := range nl.Fs {
+		t = Transform32(f, t)
+	}
+	return t
+}
+
+func (nl *NLOmt) Transform32(t float32) float32 {
+	t = 1 - t
+	if t > 0 {
+		return 1 - Transform32(nl.F, t)
+	}
+	return 1
+}
+
+func (nl *NLDescend) Transform32(t float32) float32 {
+	return 1 - Transform32(nl.F, t)
+}
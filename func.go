@@ -0,0 +1,20 @@
+package nonlinear
+
+// NLFunc v = (fn(t) - fn(0)) / (fn(1) - fn(0)) for an arbitrary monotone function fn. The inverse is
+// found numerically.
+type NLFunc struct {
+	Fn     func(float64) float64
+	V0, V1 float64 // fn(0) and fn(1)
+}
+
+func NewNLFunc(fn func(float64) float64) *NLFunc {
+	return &NLFunc{fn, fn(0), fn(1)}
+}
+
+func (nl *NLFunc) Transform(t float64) float64 {
+	return (nl.Fn(t) - nl.V0) / (nl.V1 - nl.V0)
+}
+
+func (nl *NLFunc) InvTransform(v float64) float64 {
+	return bsInv(v, nl)
+}
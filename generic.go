@@ -0,0 +1,42 @@
+package nonlinear
+
+// Float is the set of floating point types accepted by the generic interpolators.
+type Float interface {
+	~float32 | ~float64
+}
+
+// NLerpT is NLerp for any float type. The curve is evaluated in float64, the interpolation in T.
+func NLerpT[T Float](t, start, end T, f NonLinear) T {
+	if t < 0 {
+		return start
+	}
+	if t > 1 {
+		return end
+	}
+	u := T(f.Transform(float64(t)))
+	return (1-u)*start + u*end
+}
+
+// InvNLerpT is InvNLerp for any float type.
+func InvNLerpT[T Float](v, start, end T, f NonLinear) T {
+	d := end - start
+	if d == 0 {
+		if v <= start {
+			return 0
+		}
+		return 1
+	}
+	t := (v - start) / d
+	if t < 0 {
+		return 0
+	}
+	if t > 1 {
+		return 1
+	}
+	return T(f.InvTransform(float64(t)))
+}
+
+// RemapNLT is RemapNL for any float type.
+func RemapNLT[T Float](v, istart, iend, ostart, oend T, fi, fo NonLinear) T {
+	return NLerpT(InvNLerpT(v, istart, iend, fi), ostart, oend, fo)
+}
@@ -3,7 +3,11 @@ module github.com/jphsd/nonlinear
 go 1.24.5
 
 require (
-	github.com/jphsd/graphics2d v0.0.0-20250710212629-6bce14f9f02a // indirect
+	github.com/jphsd/graphics2d v0.0.0-20250710212629-6bce14f9f02a
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
 	golang.org/x/image v0.22.0 // indirect
 	golang.org/x/text v0.20.0 // indirect
 )
@@ -0,0 +1,49 @@
+package nonlinear
+
+import "encoding/gob"
+
+// The curves encode themselves for gob using their text form, see MarshalText. Registering the
+// concrete types allows them to be sent as NonLinear interface values, including within combinators.
+func init() {
+	gob.Register(&NLLinear{})
+	gob.Register(&NLSquare{})
+	gob.Register(&NLCube{})
+	gob.Register(&NLExponential{})
+	gob.Register(&NLLogarithmic{})
+	gob.Register(&NLSin{})
+	gob.Register(&NLSin1{})
+	gob.Register(&NLSin2{})
+	gob.Register(&NLCircle1{})
+	gob.Register(&NLCircle2{})
+	gob.Register(&NLLame{})
+	gob.Register(&NLCatenary{})
+	gob.Register(&NLGauss{})
+	gob.Register(&NLLogistic{})
+	gob.Register(&NLP3{})
+	gob.Register(&NLP5{})
+	gob.Register(&NLCompound{})
+	gob.Register(&NLOmt{})
+	gob.Register(&NLStopped{})
+	gob.Register(&NLSpline{})
+	gob.Register(&NLPolynomial{})
+	gob.Register(&NLSpring{})
+	gob.Register(&NLConditional{})
+	gob.Register(&NLNormalized{})
+	gob.Register(&NLExact{})
+	gob.Register(&NLDomain{})
+	gob.Register(&NLInOut{})
+	gob.Register(&NLDescend{})
+	gob.Register(&NLSoftClamp{})
+	gob.Register(&NLConstantSpeed{})
+	gob.Register(&NLInverse{})
+	gob.Register(&NLCubicBezier{})
+	gob.Register(&NLSteps{})
+	gob.Register(&NLWindow{})
+	gob.Register(&NLTable{})
+	gob.Register(&NLCached{})
+	gob.Register(&NLInvTable{})
+	gob.Register(&NLPower{})
+	gob.Register(&NLBack{})
+	gob.Register(&NLElastic{})
+	gob.Register(&NLBounce{})
+}
@@ -0,0 +1,45 @@
+package nonlinear
+
+import "math"
+
+// Rounding selects how IndexLerpRounded maps eased values to indices.
+type Rounding int
+
+const (
+	Bins    Rounding = iota // [0,1] is divided into n equal bins, one per index
+	Nearest                 // The index nearest to v * (n-1)
+	Floor                   // The index below v * (n-1)
+	Ceil                    // The index above v * (n-1)
+)
+
+// IndexLerp returns the index in [0,n) for t (clamped to [0,1]) eased by f, with each index owning an
+// equal share of f's output. n must be > 0.
+func IndexLerp(t float64, n int, f NonLinear) int {
+	return IndexLerpRounded(t, n, f, Bins)
+}
+
+// IndexLerpRounded is IndexLerp with the mapping from the eased value to an index chosen by mode.
+func IndexLerpRounded(t float64, n int, f NonLinear, mode Rounding) int {
+	v := NLerp(t, 0, 1, f)
+	var i float64
+	switch mode {
+	case Nearest:
+		i = math.Round(v * float64(n-1))
+	case Floor:
+		i = math.Floor(v * float64(n-1))
+	case Ceil:
+		i = math.Ceil(v * float64(n-1))
+	default:
+		i = math.Floor(v * float64(n))
+	}
+	return int(math.Max(0, math.Min(float64(n-1), i)))
+}
+
+// PickEased returns the item selected by IndexLerp, or the zero value if items is empty.
+func PickEased[T any](t float64, items []T, f NonLinear) T {
+	if len(items) == 0 {
+		var zero T
+		return zero
+	}
+	return items[IndexLerp(t, len(items), f)]
+}
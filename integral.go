@@ -0,0 +1,37 @@
+package nonlinear
+
+import "math"
+
+// NonLinearI is implemented by functions that can supply the definite integral of v over [a,b].
+type NonLinearI interface {
+	NonLinear
+	Integral(a, b float64) float64
+}
+
+// Integral returns the area under f between a and b, using the analytic integral if f implements
+// NonLinearI and adaptive Simpson quadrature otherwise.
+func Integral(f NonLinear, a, b float64) float64 {
+	if fi, ok := f.(NonLinearI); ok {
+		return fi.Integral(a, b)
+	}
+	fa, fm, fb := f.Transform(a), f.Transform((a+b)/2), f.Transform(b)
+	return simpson(f, a, b, fa, fm, fb, simpsonStep(a, b, fa, fm, fb), 1e-10, 50)
+}
+
+func simpsonStep(a, b, fa, fm, fb float64) float64 {
+	return (b - a) / 6 * (fa + 4*fm + fb)
+}
+
+func simpson(f NonLinear, a, b, fa, fm, fb, whole, eps float64, depth int) float64 {
+	m := (a + b) / 2
+	lm, rm := (a+m)/2, (m+b)/2
+	flm, frm := f.Transform(lm), f.Transform(rm)
+	left := simpsonStep(a, m, fa, flm, fm)
+	right := simpsonStep(m, b, fm, frm, fb)
+	delta := left + right - whole
+	if depth <= 0 || math.Abs(delta) <= 15*eps {
+		return left + right + delta/15
+	}
+	return simpson(f, a, m, fa, flm, fm, left, eps/2, depth-1) +
+		simpson(f, m, b, fm, frm, fb, right, eps/2, depth-1)
+}
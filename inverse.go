@@ -0,0 +1,175 @@
+package nonlinear
+
+import "math"
+
+// InverseOptions control the accuracy and cost of the numerical inverse.
+type InverseOptions struct {
+	Tolerance     float64 // Iteration stops once t is known to within Tolerance
+	MaxIterations int     // Upper bound on the number of evaluations of f
+}
+
+// DefaultInverseOptions are used by SolveInverse, SolveMonotone and by the curves without an analytic
+// inverse. Not safe to change concurrently with their use.
+var DefaultInverseOptions = InverseOptions{1e-12, 100}
+
+// SolveInverse is SolveMonotone, taking Newton steps whether or not f implements NonLinearD, with
+// Derivative estimating the slope of those that don't. f must be monotonic non-decreasing.
+func SolveInverse(f NonLinear, v float64) float64 {
+	return SolveInverseWith(f, v, DefaultInverseOptions)
+}
+
+// SolveInverseWith is SolveInverse with the supplied options.
+func SolveInverseWith(f NonLinear, v float64, opts InverseOptions) float64 {
+	return solveMonotone(f, v, opts, func(t float64) float64 { return Derivative(f, t) })
+}
+
+// SolveMonotone finds t in [0,1] such that f(t) = v, with v clamped to [0,1], for f monotonic
+// non-decreasing. It maintains a bracket [lo,hi] with f(lo) < v < f(hi), narrowed by Newton steps when
+// f implements NonLinearD and by bisection otherwise, until hi - lo is within the tolerance, and
+// returns its midpoint. Unless the iteration limit is reached first, the result is therefore within
+// half the tolerance of a solution. If f equals v over an interval, its midpoint is returned.
+func SolveMonotone(f NonLinear, v float64) float64 {
+	return SolveMonotoneWith(f, v, DefaultInverseOptions)
+}
+
+// SolveMonotoneWith is SolveMonotone with the supplied options.
+func SolveMonotoneWith(f NonLinear, v float64, opts InverseOptions) float64 {
+	var d func(float64) float64
+	if fd, ok := f.(NonLinearD); ok {
+		d = fd.Derivative
+	}
+	return solveMonotone(f, v, opts, d)
+}
+
+// solveMonotone implements SolveMonotone, taking Newton steps with the slopes from d, or bisecting
+// with d nil.
+func solveMonotone(f NonLinear, v float64, opts InverseOptions, d func(float64) float64) float64 {
+	if v <= 0 {
+		return 0
+	}
+	if v >= 1 {
+		return 1
+	}
+	tol := opts.Tolerance
+	lo, hi := 0.0, 1.0
+	t := v
+	for n := 0; n < opts.MaxIterations && hi-lo > tol; n++ {
+		fv := f.Transform(t) - v
+		switch {
+		case fv == 0:
+			return levelMidpoint(f, v, lo, t, hi, opts)
+		case fv < 0:
+			lo = t
+		default:
+			hi = t
+		}
+		nt := (lo + hi) / 2
+		if d != nil {
+			// Newton converges from one side, so small steps are lengthened to land beyond the
+			// solution and close the bracket from the other
+			step := -fv / d(t)
+			if math.Abs(step) < tol/2 {
+				step = math.Copysign(tol/2, step)
+			}
+			if s := t + step; s > lo && s < hi {
+				nt = s
+			}
+		}
+		t = nt
+	}
+	return (lo + hi) / 2
+}
+
+// levelMidpoint returns the midpoint of the interval around t, within [lo,hi], over which f = v,
+// given f(t) = v.
+func levelMidpoint(f NonLinear, v, lo, t, hi float64, opts InverseOptions) float64 {
+	tol := opts.Tolerance
+	if f.Transform(math.Max(lo, t-tol/2)) < v && f.Transform(math.Min(hi, t+tol/2)) > v {
+		// Not flat
+		return t
+	}
+	// Bisect for the first t with f(t) >= v, and the last with f(t) <= v
+	a, b := lo, t
+	for n := 0; n < opts.MaxIterations && b-a > tol; n++ {
+		m := (a + b) / 2
+		if f.Transform(m) < v {
+			a = m
+		} else {
+			b = m
+		}
+	}
+	c, d := t, hi
+	for n := 0; n < opts.MaxIterations && d-c > tol; n++ {
+		m := (c + d) / 2
+		if f.Transform(m) > v {
+			d = m
+		} else {
+			c = m
+		}
+	}
+	return (a + d) / 2
+}
+
+// NLInverse wraps f, replacing its inverse with a numerical one using the supplied options. This
+// allows accuracy to be traded for speed on a per curve basis.
+type NLInverse struct {
+	F       NonLinear
+	Options InverseOptions
+}
+
+func NewNLInverse(f NonLinear, tolerance float64, maxIterations int) *NLInverse {
+	return &NLInverse{f, InverseOptions{tolerance, maxIterations}}
+}
+
+func (nl *NLInverse) Transform(t float64) float64 {
+	return nl.F.Transform(t)
+}
+
+func (nl *NLInverse) InvTransform(v float64) float64 {
+	return SolveMonotoneWith(nl.F, v, nl.Options)
+}
+
+func (nl *NLInverse) Derivative(t float64) float64 {
+	return Derivative(nl.F, t)
+}
+
+func (nl *NLInverse) SecondDerivative(t float64) float64 {
+	return SecondDerivative(nl.F, t)
+}
+
+// NLInvTable wraps f, replacing its inverse with linear interpolation into a table of n evenly spaced
+// samples of it, baked at construction. InvTransform is then a table lookup, which suits curves without
+// an analytic inverse, such as NLP3, NLP5 and NLStopped, when it's called often. The inverse is least
+// accurate where f is flat, and so its inverse steep.
+type NLInvTable struct {
+	F  NonLinear
+	Ts []float64 // f^-1(i/(n-1))
+}
+
+// NewNLInvTable samples the inverse of f at n points. n < 2 is treated as 2.
+func NewNLInvTable(f NonLinear, n int) *NLInvTable {
+	if n < 2 {
+		n = 2
+	}
+	ts := make([]float64, n)
+	for i := range ts {
+		ts[i] = f.InvTransform(float64(i) / float64(n-1))
+	}
+	return &NLInvTable{f, ts}
+}
+
+func (nl *NLInvTable) Transform(t float64) float64 {
+	return nl.F.Transform(t)
+}
+
+func (nl *NLInvTable) InvTransform(v float64) float64 {
+	return lookup(nl.Ts, v)
+}
+
+func (nl *NLInvTable) Derivative(t float64) float64 {
+	return Derivative(nl.F, t)
+}
+
+func (nl *NLInvTable) SecondDerivative(t float64) float64 {
+	return SecondDerivative(nl.F, t)
+}
@@ -0,0 +1,32 @@
+package nonlinear
+
+import (
+	"math"
+	"testing"
+)
+
+// transformOnly hides the derivatives of F.
+type transformOnly struct{ NonLinear }
+
+func TestSolveInverse(t *testing.T) {
+	flat := NewNLStopped([][]float64{{0.25, 0.5}, {0.75, 0.5}})
+	tests := []struct {
+		name string
+		f    NonLinear
+		v, t float64
+	}{
+		{"p3", &NLP3{}, 0.5, 0.5},
+		{"p3", &NLP3{}, 0.15625, 0.25},
+		{"p3 transform only", transformOnly{&NLP3{}}, 0.84375, 0.75},
+		{"flat", flat, 0.5, 0.5},
+		{"clamped", &NLP3{}, -1, 0},
+		{"clamped", &NLP3{}, 2, 1},
+	}
+	for _, test := range tests {
+		for _, solve := range []func(NonLinear, float64) float64{SolveInverse, SolveMonotone} {
+			if got := solve(test.f, test.v); math.Abs(got-test.t) > 1e-9 {
+				t.Errorf("%s: solving f(t) = %g gave %g, want %g", test.name, test.v, got, test.t)
+			}
+		}
+	}
+}
@@ -0,0 +1,418 @@
+package nonlinear
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// jsonCurve is the serialized form of a curve, e.g. {"type":"logistic","params":[12,0.5]}.
+type jsonCurve struct {
+	Type   string            `json:"type"`
+	Params []float64         `json:"params,omitempty"`
+	Curves []json.RawMessage `json:"curves,omitempty"`
+}
+
+// MarshalCurve returns the JSON encoding of f, including any curves it wraps. Only curves known to
+// the registry can be encoded.
+func MarshalCurve(f NonLinear) ([]byte, error) {
+	s, ok := f.(specifier)
+	if !ok {
+		return nil, fmt.Errorf("nonlinear: %T can't be serialized", f)
+	}
+	sp := s.spec()
+	jc := jsonCurve{Type: sp.Name, Params: sp.Params}
+	for _, c := range sp.Curves {
+		b, err := MarshalCurve(c)
+		if err != nil {
+			return nil, err
+		}
+		jc.Curves = append(jc.Curves, b)
+	}
+	return json.Marshal(jc)
+}
+
+// UnmarshalCurve constructs a curve from its JSON encoding using the registry.
+func UnmarshalCurve(data []byte) (NonLinear, error) {
+	var jc jsonCurve
+	if err := json.Unmarshal(data, &jc); err != nil {
+		return nil, err
+	}
+	curves := make([]NonLinear, len(jc.Curves))
+	for i, c := range jc.Curves {
+		f, err := UnmarshalCurve(c)
+		if err != nil {
+			return nil, err
+		}
+		curves[i] = f
+	}
+	return NewWith(jc.Type, jc.Params, curves...)
+}
+
+// unmarshalInto decodes data into the curve pointed to by dst, which must be of the same type.
+func unmarshalInto(dst NonLinear, data []byte) error {
+	f, err := UnmarshalCurve(data)
+	if err != nil {
+		return err
+	}
+	dv, fv := reflect.ValueOf(dst), reflect.ValueOf(f)
+	if dv.Type() != fv.Type() {
+		return fmt.Errorf("nonlinear: can't unmarshal %T into %T", f, dst)
+	}
+	dv.Elem().Set(fv.Elem())
+	return nil
+}
+
+// Curve wraps a NonLinear so that interface valued fields, such as in configuration structs, can be
+// marshaled to and from JSON.
+type Curve struct {
+	NonLinear
+}
+
+func (c Curve) MarshalJSON() ([]byte, error) {
+	if c.NonLinear == nil {
+		return []byte("null"), nil
+	}
+	return MarshalCurve(c.NonLinear)
+}
+
+func (c *Curve) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		c.NonLinear = nil
+		return nil
+	}
+	f, err := UnmarshalCurve(data)
+	if err != nil {
+		return err
+	}
+	c.NonLinear = f
+	return nil
+}
+
+func (nl *NLLinear) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLLinear) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLSquare) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLSquare) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLCube) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLCube) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLExponential) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLExponential) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLLogarithmic) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLLogarithmic) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLSin) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLSin) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLSin1) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLSin1) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLSin2) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLSin2) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLCircle1) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLCircle1) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLCircle2) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLCircle2) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLLame) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLLame) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLCatenary) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLCatenary) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLGauss) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLGauss) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLLogistic) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLLogistic) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLP3) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLP3) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLP5) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLP5) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLCompound) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLCompound) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLOmt) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLOmt) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLStopped) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLStopped) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLSpline) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLSpline) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLPolynomial) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLPolynomial) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLSpring) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLSpring) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLConditional) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLConditional) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLNormalized) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLNormalized) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLExact) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLExact) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLDomain) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLDomain) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLInOut) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLInOut) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLDescend) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLDescend) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLSoftClamp) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLSoftClamp) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLConstantSpeed) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLConstantSpeed) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLInverse) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLInverse) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLCubicBezier) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLCubicBezier) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLSteps) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLSteps) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLPower) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLPower) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLBack) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLBack) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLElastic) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLElastic) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLBounce) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLBounce) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLWindow) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLWindow) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLTable) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLTable) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLCached) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLCached) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
+
+func (nl *NLInvTable) MarshalJSON() ([]byte, error) {
+	return MarshalCurve(nl)
+}
+
+func (nl *NLInvTable) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(nl, data)
+}
@@ -0,0 +1,80 @@
+package nonlinear
+
+import "sort"
+
+// KeyframeTrack is an editable set of keys, each a time, a value and the curve easing the segment
+// from it to the next key, evaluated with MultiLerp. Times are kept ascending by the editing methods;
+// if the fields are set directly they must be ascending and of the same length.
+type KeyframeTrack struct {
+	Times  []float64
+	Values []float64
+	Curves []NonLinear // Curves[i] eases from key i to key i+1, nil for linear
+}
+
+// Evaluate returns the value of the track at t, clamped to the first and last keys.
+func (kt *KeyframeTrack) Evaluate(t float64) float64 {
+	return MultiLerp(t, kt.Times, kt.Values, kt.Curves)
+}
+
+// Duration returns the time from the first key to the last.
+func (kt *KeyframeTrack) Duration() float64 {
+	n := len(kt.Times)
+	if n == 0 {
+		return 0
+	}
+	return kt.Times[n-1] - kt.Times[0]
+}
+
+// Len returns the number of keys.
+func (kt *KeyframeTrack) Len() int {
+	return len(kt.Times)
+}
+
+// Insert adds a key at t, replacing any key already there, and returns its index.
+func (kt *KeyframeTrack) Insert(t, v float64, curve NonLinear) int {
+	kt.pad()
+	i := sort.SearchFloat64s(kt.Times, t)
+	if i < len(kt.Times) && kt.Times[i] == t {
+		kt.Values[i], kt.Curves[i] = v, curve
+		return i
+	}
+	kt.Times = append(kt.Times, 0)
+	kt.Values = append(kt.Values, 0)
+	kt.Curves = append(kt.Curves, nil)
+	copy(kt.Times[i+1:], kt.Times[i:])
+	copy(kt.Values[i+1:], kt.Values[i:])
+	copy(kt.Curves[i+1:], kt.Curves[i:])
+	kt.Times[i], kt.Values[i], kt.Curves[i] = t, v, curve
+	return i
+}
+
+// Remove deletes key i.
+func (kt *KeyframeTrack) Remove(i int) {
+	kt.pad()
+	kt.Times = append(kt.Times[:i], kt.Times[i+1:]...)
+	kt.Values = append(kt.Values[:i], kt.Values[i+1:]...)
+	kt.Curves = append(kt.Curves[:i], kt.Curves[i+1:]...)
+}
+
+// Shift moves key i by dt, along with its value and curve, replacing any key at its new time.
+// Returns the key's new index.
+func (kt *KeyframeTrack) Shift(i int, dt float64) int {
+	kt.pad()
+	t, v, f := kt.Times[i]+dt, kt.Values[i], kt.Curves[i]
+	kt.Remove(i)
+	return kt.Insert(t, v, f)
+}
+
+// Offset moves every key by dt.
+func (kt *KeyframeTrack) Offset(dt float64) {
+	for i := range kt.Times {
+		kt.Times[i] += dt
+	}
+}
+
+// pad extends Curves to the length of Times, as MultiLerp allows it to be shorter.
+func (kt *KeyframeTrack) pad() {
+	for len(kt.Curves) < len(kt.Times) {
+		kt.Curves = append(kt.Curves, nil)
+	}
+}
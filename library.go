@@ -0,0 +1,50 @@
+package nonlinear
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadLibrary reads a YAML (or JSON) document mapping names to curve definitions. Each definition is
+// either a Parse expression or the object form used by MarshalCurve, for example:
+//
+//	standard: compound(p3, sin)
+//	emphasized: "stops(0.25:0.1, 0.75:0.9)"
+//	decelerate:
+//	  type: omt
+//	  curves:
+//	    - type: cube
+func LoadLibrary(r io.Reader) (map[string]NonLinear, error) {
+	var doc map[string]any
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if err == io.EOF {
+			return map[string]NonLinear{}, nil
+		}
+		return nil, err
+	}
+	lib := make(map[string]NonLinear, len(doc))
+	for name, def := range doc {
+		var f NonLinear
+		var err error
+		switch d := def.(type) {
+		case string:
+			f, err = Parse(d)
+		case map[string]any:
+			var b []byte
+			b, err = json.Marshal(d)
+			if err == nil {
+				f, err = UnmarshalCurve(b)
+			}
+		default:
+			err = fmt.Errorf("nonlinear: unexpected definition %v", def)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		lib[name] = f
+	}
+	return lib, nil
+}
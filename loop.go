@@ -0,0 +1,59 @@
+package nonlinear
+
+import (
+	"math"
+	"time"
+)
+
+// LoopMode is how a looping Tween or Timeline plays each repeat.
+type LoopMode int
+
+const (
+	LoopRestart  LoopMode = iota // Each play starts again from the beginning
+	LoopPingPong                 // Alternate plays run backwards, through the mirror of the curve in time
+)
+
+// RepeatForever as a Repeat value loops without end.
+const RepeatForever = -1
+
+// Forever is the TotalDuration of an animation that loops without end.
+const Forever = time.Duration(math.MaxInt64)
+
+// loopTime returns the time into the current play and the index of the play, for time t into repeat+1
+// plays of length d. Past the last play, it's the end of the last play. Backwards plays have their time
+// mirrored.
+func loopTime(t, d time.Duration, repeat int, mode LoopMode) (time.Duration, int) {
+	if d <= 0 {
+		if repeat < 0 {
+			return 0, 0
+		}
+		return 0, repeat
+	}
+	k, u := int(t/d), t%d
+	if repeat >= 0 && k > repeat {
+		k, u = repeat, d
+	}
+	if mode == LoopPingPong && k%2 == 1 {
+		u = d - u
+	}
+	return u, k
+}
+
+// loopDuration returns the length of repeat+1 plays of length d, or Forever.
+func loopDuration(d time.Duration, repeat int) time.Duration {
+	switch {
+	case d <= 0:
+		return 0
+	case repeat < 0 || d > Forever/time.Duration(repeat+1):
+		return Forever
+	}
+	return d * time.Duration(repeat+1)
+}
+
+// addDuration returns a+b, or Forever if either is.
+func addDuration(a, b time.Duration) time.Duration {
+	if a == Forever || b == Forever || a > Forever-b {
+		return Forever
+	}
+	return a + b
+}
@@ -0,0 +1,50 @@
+package nonlinear
+
+import "math"
+
+// BakeLUT8 returns f sampled at the 256 8-bit levels and quantized to 8 bits, for applying a curve
+// directly to 8-bit image data. Values are clamped to [0,1] and rounded to the nearest level.
+func BakeLUT8(f NonLinear) [256]uint8 {
+	var lut [256]uint8
+	bake(f, len(lut), 0xff, false, func(i int, v float64) { lut[i] = uint8(v) })
+	return lut
+}
+
+// BakeLUT8Dither is BakeLUT8 with the quantization error diffused along the table, so that runs of
+// entries on a shallow part of the curve average to the exact value rather than banding. The table
+// may no longer be monotonic.
+func BakeLUT8Dither(f NonLinear) [256]uint8 {
+	var lut [256]uint8
+	bake(f, len(lut), 0xff, true, func(i int, v float64) { lut[i] = uint8(v) })
+	return lut
+}
+
+// BakeLUT16 returns f sampled at the 65536 16-bit levels and quantized to 16 bits.
+func BakeLUT16(f NonLinear) [65536]uint16 {
+	var lut [65536]uint16
+	bake(f, len(lut), 0xffff, false, func(i int, v float64) { lut[i] = uint16(v) })
+	return lut
+}
+
+// BakeLUT16Dither is BakeLUT16 with the quantization error diffused along the table.
+func BakeLUT16Dither(f NonLinear) [65536]uint16 {
+	var lut [65536]uint16
+	bake(f, len(lut), 0xffff, true, func(i int, v float64) { lut[i] = uint16(v) })
+	return lut
+}
+
+// bake samples f at n evenly spaced points, scales the values to [0,max] and passes the quantized
+// results to set.
+func bake(f NonLinear, n int, max float64, dither bool, set func(int, float64)) {
+	vs := make([]float64, n)
+	TransformSlice(f, vs)
+	e := 0.0
+	for i, v := range vs {
+		v = math.Max(0, math.Min(1, v))*max + e
+		q := math.Max(0, math.Min(max, math.Round(v)))
+		if dither {
+			e = v - q
+		}
+		set(i, q)
+	}
+}
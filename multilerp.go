@@ -0,0 +1,28 @@
+package nonlinear
+
+import "sort"
+
+// MultiLerp evaluates the piecewise interpolation of values at t, where values[i] is reached at
+// times[i] and segment i, from times[i] to times[i+1], is eased with curves[i]. times must be
+// ascending and the same length as values. Missing or nil curves are treated as NLLinear. t is
+// clamped to [times[0], times[n-1]].
+func MultiLerp(t float64, times, values []float64, curves []NonLinear) float64 {
+	n := len(times)
+	if n == 0 {
+		return 0
+	}
+	if t <= times[0] {
+		return values[0]
+	}
+	if t >= times[n-1] {
+		return values[n-1]
+	}
+	i := sort.SearchFloat64s(times, t)
+	// times[i-1] < t <= times[i]
+	var f NonLinear = &NLLinear{}
+	if i-1 < len(curves) && curves[i-1] != nil {
+		f = curves[i-1]
+	}
+	t0, t1 := times[i-1], times[i]
+	return NLerp((t-t0)/(t1-t0), values[i-1], values[i], f)
+}
@@ -6,6 +6,7 @@ package nonlinear
  */
 
 // NLerp returns the value of the supplied non-linear function at t. Note t is clamped to [0,1]
+// start may be greater than end, in which case the result decreases from start to end as t increases.
 func NLerp(t, start, end float64, f NonLinear) float64 {
 	if t < 0 {
 		return start
@@ -18,8 +19,17 @@ func NLerp(t, start, end float64, f NonLinear) float64 {
 }
 
 // InvNLerp performs the inverse of NLerp and returns the value of t for a value v (clamped to [start, end]).
+// The range may be descending, start > end. For a zero width range, start == end, the result is 0 if
+// v <= start and 1 otherwise.
 func InvNLerp(v, start, end float64, f NonLinear) float64 {
-	t := (v - start) / (end - start)
+	d := end - start
+	if d == 0 {
+		if v <= start {
+			return 0
+		}
+		return 1
+	}
+	t := (v - start) / d
 	if t < 0 {
 		return 0
 	}
@@ -34,3 +44,91 @@ func InvNLerp(v, start, end float64, f NonLinear) float64 {
 func RemapNL(v, istart, iend, ostart, oend float64, fi, fo NonLinear) float64 {
 	return NLerp(InvNLerp(v, istart, iend, fi), ostart, oend, fo)
 }
+
+// NLerpUnclamped is NLerp without the clamping of t to [0,1]. Only use with functions that are
+// defined outside of [0,1], such as NLSoftClamp.
+func NLerpUnclamped(t, start, end float64, f NonLinear) float64 {
+	t = f.Transform(t)
+	return (1-t)*start + t*end
+}
+
+// NLerpDescending is the equivalent of NLerp for a decreasing function f, where f(0) = 1 and f(1) = 0.
+// The result is start at t=0 and end at t=1. Note t is clamped to [0,1]
+func NLerpDescending(t, start, end float64, f NonLinear) float64 {
+	if t < 0 {
+		return start
+	}
+	if t > 1 {
+		return end
+	}
+	t = f.Transform(t)
+	return t*start + (1-t)*end
+}
+
+// InvNLerpDescending performs the inverse of NLerpDescending and returns the value of t for a value v
+// (clamped to [start, end]). For a zero width range the result is 1 if v <= start and 0 otherwise.
+func InvNLerpDescending(v, start, end float64, f NonLinear) float64 {
+	d := start - end
+	if d == 0 {
+		if v <= start {
+			return 1
+		}
+		return 0
+	}
+	t := (v - end) / d
+	if t < 0 {
+		return 1
+	}
+	if t > 1 {
+		return 0
+	}
+	return f.InvTransform(t)
+}
+
+// NLerpVec sets each component of dst to the interpolation between the corresponding components of
+// start and end, using a single evaluation of f at t (clamped to [0,1]). If dst is nil or too short, a
+// new slice is allocated. start and end must have the same length. Returns dst.
+func NLerpVec(t float64, start, end []float64, f NonLinear, dst []float64) []float64 {
+	n := len(start)
+	if len(dst) < n {
+		dst = make([]float64, n)
+	}
+	dst = dst[:n]
+	switch {
+	case t < 0:
+		copy(dst, start)
+	case t > 1:
+		copy(dst, end[:n])
+	default:
+		t = f.Transform(t)
+		for i, s := range start {
+			dst[i] = (1-t)*s + t*end[i]
+		}
+	}
+	return dst
+}
+
+// NLerpPoint2 interpolates between the 2D points p0 and p1 using f at t (clamped to [0,1]).
+// For graphics2d's []float64 points, use NLerpVec.
+func NLerpPoint2(t float64, p0, p1 [2]float64, f NonLinear) [2]float64 {
+	var p [2]float64
+	NLerpVec(t, p0[:], p1[:], f, p[:])
+	return p
+}
+
+// NLerpPoint3 interpolates between the 3D points p0 and p1 using f at t (clamped to [0,1]).
+func NLerpPoint3(t float64, p0, p1 [3]float64, f NonLinear) [3]float64 {
+	var p [3]float64
+	NLerpVec(t, p0[:], p1[:], f, p[:])
+	return p
+}
+
+// NLerp2D performs bilinear interpolation between the corner values v00 (u=0, v=0), v10 (u=1, v=0),
+// v01 (u=0, v=1) and v11, easing u with fu and v with fv. u and v are clamped to [0,1].
+func NLerp2D(u, v, v00, v10, v01, v11 float64, fu, fv NonLinear) float64 {
+	u = NLerp(u, 0, 1, fu)
+	v = NLerp(v, 0, 1, fv)
+	v0 := (1-u)*v00 + u*v10
+	v1 := (1-u)*v01 + u*v11
+	return (1-v)*v0 + v*v1
+}
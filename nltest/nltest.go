@@ -0,0 +1,123 @@
+// Package nltest checks that an implementation of nonlinear.NonLinear meets the contract relied on by
+// package nonlinear: exact endpoints, monotonicity, an inverse that round trips and, if supplied,
+// derivatives consistent with the transform. It's intended for use in the tests of user curves:
+//
+//	func TestMyCurve(t *testing.T) {
+//		nltest.TestCurve(t, &MyCurve{}, nltest.DefaultOptions)
+//	}
+package nltest
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/jphsd/nonlinear"
+)
+
+// Options set the sampling and tolerances for TestCurve.
+type Options struct {
+	Samples                   int     // Number of intervals [0,1] is sampled at
+	EndpointTolerance         float64 // Allowed |f(0)| and |f(1) - 1|, 0 for exact
+	RoundTripTolerance        float64 // Allowed |InvTransform(Transform(t)) - t|
+	DerivativeTolerance       float64 // Allowed difference from finite differences, relative to max(1, |d|)
+	SecondDerivativeTolerance float64 // As DerivativeTolerance, for the less accurate second differences
+}
+
+// DefaultOptions are strict enough for curves built from the standard library math functions. Curves
+// with near vertical sections, such as NLCircle2 close to 0, may need a larger SecondDerivativeTolerance.
+var DefaultOptions = Options{1000, 0, 1e-9, 1e-4, 1e-2}
+
+// sample returns the ith of n+1 evenly spaced t from 0 to 1.
+func sample(i, n int) float64 {
+	return float64(i) / float64(n)
+}
+
+// CheckEndpoints returns an error if f(0) isn't within tol of 0, or f(1) within tol of 1.
+func CheckEndpoints(f nonlinear.NonLinear, tol float64) error {
+	if v := f.Transform(0); !(math.Abs(v) <= tol) {
+		return fmt.Errorf("nltest: f(0) = %g, want 0", v)
+	}
+	if v := f.Transform(1); !(math.Abs(v-1) <= tol) {
+		return fmt.Errorf("nltest: f(1) = %g, want 1", v)
+	}
+	return nil
+}
+
+// CheckMonotonic returns an error if f is non-finite or decreases anywhere over samples+1 evenly
+// spaced t in [0,1].
+func CheckMonotonic(f nonlinear.NonLinear, samples int) error {
+	samples = max(samples, 1)
+	pv := math.Inf(-1)
+	for i := 0; i <= samples; i++ {
+		t := sample(i, samples)
+		v := f.Transform(t)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("nltest: f(%g) = %g, want finite", t, v)
+		}
+		if v < pv {
+			return fmt.Errorf("nltest: f decreases from %g to %g at t = %g", pv, v, t)
+		}
+		pv = v
+	}
+	return nil
+}
+
+// CheckRoundTrip returns an error if InvTransform(Transform(t)) isn't within tol of t for any of
+// samples+1 evenly spaced t in [0,1]. Where f is flat any t mapping to the same v is accepted.
+func CheckRoundTrip(f nonlinear.NonLinear, samples int, tol float64) error {
+	samples = max(samples, 1)
+	for i := 0; i <= samples; i++ {
+		t := sample(i, samples)
+		v := f.Transform(t)
+		it := f.InvTransform(v)
+		if math.Abs(it-t) <= tol || f.Transform(it) == v {
+			continue
+		}
+		return fmt.Errorf("nltest: InvTransform(f(%g)) = %g, error %g exceeds %g", t, it, math.Abs(it-t), tol)
+	}
+	return nil
+}
+
+// CheckDerivatives returns an error if the analytic derivatives of f, if it implements
+// nonlinear.NonLinearD or NonLinearD2, differ from finite differences by more than tol, or tol2 for the
+// second derivative, relative to max(1, |d|). t is sampled at the midpoints of samples intervals,
+// which avoids the ends and the kinks of curves such as NLStopped with stops at simple fractions.
+func CheckDerivatives(f nonlinear.NonLinear, samples int, tol, tol2 float64) error {
+	samples = max(samples, 1)
+	fd, ok := f.(nonlinear.NonLinearD)
+	if !ok {
+		return nil
+	}
+	fd2, ok2 := f.(nonlinear.NonLinearD2)
+	for i := 0; i < samples; i++ {
+		t := (float64(i) + 0.5) / float64(samples)
+		d, fdd := fd.Derivative(t), nonlinear.FiniteDerivative(f, t)
+		if !(math.Abs(d-fdd) <= tol*math.Max(1, math.Abs(fdd))) {
+			return fmt.Errorf("nltest: Derivative(%g) = %g, finite difference %g", t, d, fdd)
+		}
+		if !ok2 {
+			continue
+		}
+		d2, fdd2 := fd2.SecondDerivative(t), nonlinear.FiniteSecondDerivative(f, t)
+		if !(math.Abs(d2-fdd2) <= tol2*math.Max(1, math.Abs(fdd2))) {
+			return fmt.Errorf("nltest: SecondDerivative(%g) = %g, finite difference %g", t, d2, fdd2)
+		}
+	}
+	return nil
+}
+
+// TestCurve runs all of the checks on f, reporting each failure with t.Error.
+func TestCurve(t testing.TB, f nonlinear.NonLinear, opts Options) {
+	t.Helper()
+	for _, err := range []error{
+		CheckEndpoints(f, opts.EndpointTolerance),
+		CheckMonotonic(f, opts.Samples),
+		CheckRoundTrip(f, opts.Samples, opts.RoundTripTolerance),
+		CheckDerivatives(f, opts.Samples, opts.DerivativeTolerance, opts.SecondDerivativeTolerance),
+	} {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package nltest_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jphsd/nonlinear"
+	"github.com/jphsd/nonlinear/bench"
+	"github.com/jphsd/nonlinear/nltest"
+)
+
+// overshoots lists the registered curves that aren't monotonic by design, for which only the end
+// points and derivatives are checked.
+var overshoots = map[string]bool{
+	"back":             true,
+	"bounce":           true,
+	"easeinback":       true,
+	"easeinbounce":     true,
+	"easeinelastic":    true,
+	"easeinoutback":    true,
+	"easeinoutbounce":  true,
+	"easeinoutelastic": true,
+	"easeoutback":      true,
+	"easeoutbounce":    true,
+	"easeoutelastic":   true,
+	"elastic":          true,
+}
+
+// tolerances adjusts DefaultOptions for curves that are approximations, or have features finite
+// differences can't follow.
+var tolerances = map[string]func(o *nltest.Options){
+	// Rounding in the polynomial
+	"back":        func(o *nltest.Options) { o.EndpointTolerance = 1e-15 },
+	"easeinback":  func(o *nltest.Options) { o.EndpointTolerance = 1e-15 },
+	"easeoutback": func(o *nltest.Options) { o.EndpointTolerance = 1e-15 },
+
+	// Near vertical ends
+	"circle1":       func(o *nltest.Options) { o.SecondDerivativeTolerance = 0.05 },
+	"circle2":       func(o *nltest.Options) { o.SecondDerivativeTolerance = 0.05 },
+	"domain":        func(o *nltest.Options) { o.SecondDerivativeTolerance = 0.05 },
+	"easeincirc":    func(o *nltest.Options) { o.SecondDerivativeTolerance = 0.05 },
+	"easeinoutcirc": func(o *nltest.Options) { o.SecondDerivativeTolerance = 0.05 },
+	"easeoutcirc":   func(o *nltest.Options) { o.SecondDerivativeTolerance = 0.05 },
+	"lame":          func(o *nltest.Options) { o.SecondDerivativeTolerance = 0.05 },
+
+	// Kinks between bounces and table entries
+	"easeinoutbounce": func(o *nltest.Options) { o.SecondDerivativeTolerance = math.Inf(1) },
+	"constantspeed":   func(o *nltest.Options) { o.SecondDerivativeTolerance = math.Inf(1) },
+	"cached":          table,
+	"table":           table,
+	"invtable":        func(o *nltest.Options) { o.RoundTripTolerance = 1e-2 },
+	"inverse":         func(o *nltest.Options) { o.RoundTripTolerance = 1e-6 },
+
+	// The Fast Transforms are approximations, their inverses and derivatives aren't
+	"exponential_fast": fast,
+	"gauss_fast":       fast,
+	"logistic_fast":    fast,
+	"power_fast":       fast,
+	"sin_fast": func(o *nltest.Options) {
+		o.EndpointTolerance, o.RoundTripTolerance, o.DerivativeTolerance = 1e-4, 1e-2, 1e-2
+	},
+
+	// Not quite settled by the end of the example's duration
+	"spring": func(o *nltest.Options) { o.EndpointTolerance = 1e-4 },
+}
+
+func fast(o *nltest.Options) {
+	o.RoundTripTolerance, o.DerivativeTolerance = 1e-4, 1e-3
+}
+
+// table is for the curves interpolating a table, whose inverses are worst where the curve is flat.
+func table(o *nltest.Options) {
+	o.RoundTripTolerance, o.DerivativeTolerance, o.SecondDerivativeTolerance = 1e-2, 0.05, math.Inf(1)
+}
+
+func TestRegistered(t *testing.T) {
+	for _, info := range nonlinear.ListCurves() {
+		t.Run(info.Name, func(t *testing.T) {
+			var f nonlinear.NonLinear
+			var err error
+			if ex, ok := bench.Examples[info.Name]; ok {
+				f, err = nonlinear.Parse(ex)
+			} else {
+				f, err = nonlinear.New(info.Name)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			opts := nltest.DefaultOptions
+			if fn, ok := tolerances[info.Name]; ok {
+				fn(&opts)
+			}
+
+			switch {
+			case info.Name == "descend":
+				// Runs from 1 to 0
+				err = nltest.CheckRoundTrip(f, opts.Samples, opts.RoundTripTolerance)
+			case overshoots[info.Name]:
+				err = nltest.CheckEndpoints(f, opts.EndpointTolerance)
+			default:
+				nltest.TestCurve(t, f, opts)
+				return
+			}
+			if err != nil {
+				t.Error(err)
+			}
+			err = nltest.CheckDerivatives(f, opts.Samples, opts.DerivativeTolerance, opts.SecondDerivativeTolerance)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
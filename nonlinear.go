@@ -9,6 +9,18 @@ type NonLinear interface {
 	InvTransform(v float64) float64
 }
 
+// NonLinearD is implemented by functions that can supply their first derivative, dv/dt, at t.
+type NonLinearD interface {
+	NonLinear
+	Derivative(t float64) float64
+}
+
+// NonLinearD2 is implemented by functions that can also supply their second derivative, d2v/dt2, at t.
+type NonLinearD2 interface {
+	NonLinearD
+	SecondDerivative(t float64) float64
+}
+
 // NLLinear v = t
 type NLLinear struct{}
 
@@ -20,6 +32,18 @@ func (nl *NLLinear) InvTransform(v float64) float64 {
 	return v
 }
 
+func (nl *NLLinear) Derivative(t float64) float64 {
+	return 1
+}
+
+func (nl *NLLinear) SecondDerivative(t float64) float64 {
+	return 0
+}
+
+func (nl *NLLinear) Integral(a, b float64) float64 {
+	return (b*b - a*a) / 2
+}
+
 // NLSquare v = t^2
 type NLSquare struct{}
 
@@ -31,6 +55,18 @@ func (nl *NLSquare) InvTransform(v float64) float64 {
 	return math.Sqrt(v)
 }
 
+func (nl *NLSquare) Derivative(t float64) float64 {
+	return 2 * t
+}
+
+func (nl *NLSquare) SecondDerivative(t float64) float64 {
+	return 2
+}
+
+func (nl *NLSquare) Integral(a, b float64) float64 {
+	return (b*b*b - a*a*a) / 3
+}
+
 // NLCube v = t^3
 type NLCube struct{}
 
@@ -42,24 +78,52 @@ func (nl *NLCube) InvTransform(v float64) float64 {
 	return math.Pow(v, 1/3.0)
 }
 
+func (nl *NLCube) Derivative(t float64) float64 {
+	return 3 * t * t
+}
+
+func (nl *NLCube) SecondDerivative(t float64) float64 {
+	return 6 * t
+}
+
+func (nl *NLCube) Integral(a, b float64) float64 {
+	return (b*b*b*b - a*a*a*a) / 4
+}
+
 // NLExponential v = (exp(t*k) - 1) * scale
 type NLExponential struct {
 	K     float64
 	Scale float64
+	Fast  bool // Use an approximation of exp, see NewNLExponentialFast
 }
 
 func NewNLExponential(k float64) *NLExponential {
-	return &NLExponential{k, 1 / (math.Exp(k) - 1)}
+	return &NLExponential{K: k, Scale: 1 / math.Expm1(k)}
 }
 
 func (nl *NLExponential) Transform(t float64) float64 {
-	return (math.Exp(t*nl.K) - 1) * nl.Scale
+	if nl.Fast {
+		return (fastExp(t*nl.K) - 1) * nl.Scale
+	}
+	return math.Expm1(t*nl.K) * nl.Scale
 }
 
 func (nl *NLExponential) InvTransform(v float64) float64 {
 	return math.Log1p(v/nl.Scale) / nl.K
 }
 
+func (nl *NLExponential) Derivative(t float64) float64 {
+	return nl.K * math.Exp(t*nl.K) * nl.Scale
+}
+
+func (nl *NLExponential) SecondDerivative(t float64) float64 {
+	return nl.K * nl.K * math.Exp(t*nl.K) * nl.Scale
+}
+
+func (nl *NLExponential) Integral(a, b float64) float64 {
+	return ((math.Exp(b*nl.K)-math.Exp(a*nl.K))/nl.K - (b - a)) * nl.Scale
+}
+
 // NLLogarithmic v = log(1+t*k) * scale
 type NLLogarithmic struct {
 	K     float64
@@ -78,10 +142,31 @@ func (nl *NLLogarithmic) InvTransform(v float64) float64 {
 	return (math.Exp(v/nl.Scale) - 1) / nl.K
 }
 
+func (nl *NLLogarithmic) Derivative(t float64) float64 {
+	return nl.K * nl.Scale / (1 + t*nl.K)
+}
+
+func (nl *NLLogarithmic) SecondDerivative(t float64) float64 {
+	d := 1 + t*nl.K
+	return -nl.K * nl.K * nl.Scale / (d * d)
+}
+
+func (nl *NLLogarithmic) Integral(a, b float64) float64 {
+	af := func(t float64) float64 {
+		return (1+t*nl.K)*math.Log1p(t*nl.K)/nl.K - t
+	}
+	return (af(b) - af(a)) * nl.Scale
+}
+
 // NLSin v = sin(t) with t mapped to [-Pi/2,Pi/2]
-type NLSin struct{} // first derivative 0 at t=0,1
+type NLSin struct { // first derivative 0 at t=0,1
+	Fast bool // Use an approximation of sin, see NewNLSinFast
+}
 
 func (nl *NLSin) Transform(t float64) float64 {
+	if nl.Fast && t >= 0 && t <= 1 {
+		return (fastSin((t-0.5)*math.Pi) + 1) / 2
+	}
 	return (math.Sin((t-0.5)*math.Pi) + 1) / 2
 }
 
@@ -89,6 +174,18 @@ func (nl *NLSin) InvTransform(v float64) float64 {
 	return math.Asin((v*2)-1)/math.Pi + 0.5
 }
 
+func (nl *NLSin) Derivative(t float64) float64 {
+	return math.Cos((t-0.5)*math.Pi) * math.Pi / 2
+}
+
+func (nl *NLSin) SecondDerivative(t float64) float64 {
+	return -math.Sin((t-0.5)*math.Pi) * math.Pi * math.Pi / 2
+}
+
+func (nl *NLSin) Integral(a, b float64) float64 {
+	return ((math.Cos((a-0.5)*math.Pi)-math.Cos((b-0.5)*math.Pi))/math.Pi + b - a) / 2
+}
+
 // NLSin1 v = sin(t) with t mapped to [0,Pi/2]
 type NLSin1 struct{} // first derivative 0 at t=1
 
@@ -100,6 +197,18 @@ func (nl *NLSin1) InvTransform(v float64) float64 {
 	return math.Asin(v) / math.Pi * 2
 }
 
+func (nl *NLSin1) Derivative(t float64) float64 {
+	return math.Cos(t*math.Pi/2) * math.Pi / 2
+}
+
+func (nl *NLSin1) SecondDerivative(t float64) float64 {
+	return -math.Sin(t*math.Pi/2) * math.Pi * math.Pi / 4
+}
+
+func (nl *NLSin1) Integral(a, b float64) float64 {
+	return (math.Cos(a*math.Pi/2) - math.Cos(b*math.Pi/2)) * 2 / math.Pi
+}
+
 // NLSin2 v = sin(t) with t mapped to [-Pi/2,0]
 type NLSin2 struct{} // first derivative 0 at t=0,1
 
@@ -111,6 +220,18 @@ func (nl *NLSin2) InvTransform(v float64) float64 {
 	return math.Asin(v-1)*2/math.Pi + 1
 }
 
+func (nl *NLSin2) Derivative(t float64) float64 {
+	return math.Cos((t-1)*math.Pi/2) * math.Pi / 2
+}
+
+func (nl *NLSin2) SecondDerivative(t float64) float64 {
+	return -math.Sin((t-1)*math.Pi/2) * math.Pi * math.Pi / 4
+}
+
+func (nl *NLSin2) Integral(a, b float64) float64 {
+	return (math.Cos((a-1)*math.Pi/2)-math.Cos((b-1)*math.Pi/2))*2/math.Pi + b - a
+}
+
 // NLCircle1 v = 1 - sqrt(1-t^2)
 type NLCircle1 struct{}
 
@@ -128,6 +249,20 @@ func (nl *NLCircle1) InvTransform(v float64) float64 {
 	return 1
 }
 
+func (nl *NLCircle1) Derivative(t float64) float64 {
+	if t < 1 {
+		return t / math.Sqrt(1-t*t)
+	}
+	return math.Inf(1)
+}
+
+func (nl *NLCircle1) SecondDerivative(t float64) float64 {
+	if t < 1 {
+		return math.Pow(1-t*t, -1.5)
+	}
+	return math.Inf(1)
+}
+
 // NLCircle2 v = sqrt(2t-t^2)
 type NLCircle2 struct{}
 
@@ -139,6 +274,14 @@ func (nl *NLCircle2) InvTransform(v float64) float64 {
 	return 1 - math.Sqrt(1-v*v)
 }
 
+func (nl *NLCircle2) Derivative(t float64) float64 {
+	return (1 - t) / math.Sqrt(t*(2-t))
+}
+
+func (nl *NLCircle2) SecondDerivative(t float64) float64 {
+	return -math.Pow(t*(2-t), -1.5)
+}
+
 // NLLame (aka superellipse) v = 1 - (1-t^n)^1/m
 type NLLame struct {
 	N   float64
@@ -168,6 +311,23 @@ func (nl *NLLame) InvTransform(v float64) float64 {
 	return 1
 }
 
+func (nl *NLLame) Derivative(t float64) float64 {
+	tn1 := math.Pow(t, nl.N-1)
+	vm := 1 - tn1*t
+	return nl.N * tn1 * math.Pow(vm, nl.Odm-1) * nl.Odm
+}
+
+func (nl *NLLame) SecondDerivative(t float64) float64 {
+	a := nl.Odm
+	w := 1 - math.Pow(t, nl.N)
+	w1 := -nl.N * math.Pow(t, nl.N-1)
+	w2 := 0.0
+	if nl.N != 1 {
+		w2 = -nl.N * (nl.N - 1) * math.Pow(t, nl.N-2)
+	}
+	return -a * ((a-1)*math.Pow(w, a-2)*w1*w1 + math.Pow(w, a-1)*w2)
+}
+
 // NLCatenary v = cosh(t)
 type NLCatenary struct{}
 
@@ -179,20 +339,36 @@ func (nl *NLCatenary) InvTransform(v float64) float64 {
 	return math.Acosh(v*(math.Cosh(1)-1) + 1)
 }
 
+func (nl *NLCatenary) Derivative(t float64) float64 {
+	return math.Sinh(t) / (math.Cosh(1) - 1)
+}
+
+func (nl *NLCatenary) SecondDerivative(t float64) float64 {
+	return math.Cosh(t) / (math.Cosh(1) - 1)
+}
+
+func (nl *NLCatenary) Integral(a, b float64) float64 {
+	return (math.Sinh(b) - math.Sinh(a) - (b - a)) / (math.Cosh(1) - 1)
+}
+
 // NLGauss v = gauss(t, k)
 type NLGauss struct {
 	K, Offs, Scale float64
+	Fast           bool // Use an approximation of exp, see NewNLGaussFast
 }
 
 func NewNLGauss(k float64) *NLGauss {
 	offs := math.Exp(-k * k * 0.5)
 	scale := 1 / (1 - offs)
-	return &NLGauss{k, offs, scale}
+	return &NLGauss{K: k, Offs: offs, Scale: scale}
 }
 
 func (nl *NLGauss) Transform(t float64) float64 {
 	x := nl.K * (t - 1)
 	x *= -0.5 * x
+	if nl.Fast {
+		return (fastExp(x) - nl.Offs) * nl.Scale
+	}
 	return (math.Exp(x) - nl.Offs) * nl.Scale
 }
 
@@ -205,22 +381,36 @@ func (nl *NLGauss) InvTransform(v float64) float64 {
 	return 1 - v/nl.K
 }
 
+func (nl *NLGauss) Derivative(t float64) float64 {
+	x := nl.K * (t - 1)
+	return -nl.K * x * math.Exp(-0.5*x*x) * nl.Scale
+}
+
+func (nl *NLGauss) SecondDerivative(t float64) float64 {
+	x := nl.K * (t - 1)
+	return -nl.K * nl.K * math.Exp(-0.5*x*x) * (1 - x*x) * nl.Scale
+}
+
 // NLLogistic v = logistic(t, k, mp)
 type NLLogistic struct {
 	K, Mp, Offs, Scale float64
+	Fast               bool // Use an approximation of exp, see NewNLLogisticFast
 }
 
-// k > 0 and mp (0,1) - not checked
+// k > 0 and mp (0,1) - not checked, see NewNLLogisticChecked
 func NewNLLogistic(k, mp float64) *NLLogistic {
 	v0 := -mp * k
 	v0 = logisticTransform(v0)
 	v1 := (1 - mp) * k
 	v1 = logisticTransform(v1)
-	return &NLLogistic{k, mp, v0, 1 / (v1 - v0)}
+	return &NLLogistic{K: k, Mp: mp, Offs: v0, Scale: 1 / (v1 - v0)}
 }
 
 func (nl *NLLogistic) Transform(t float64) float64 {
 	t = (t - nl.Mp) * nl.K
+	if nl.Fast {
+		return (1/(1+fastExp(-t)) - nl.Offs) * nl.Scale
+	}
 	return (logisticTransform(t) - nl.Offs) * nl.Scale
 }
 
@@ -231,6 +421,16 @@ func (nl *NLLogistic) InvTransform(v float64) float64 {
 	return v/nl.K + nl.Mp
 }
 
+func (nl *NLLogistic) Derivative(t float64) float64 {
+	s := logisticTransform((t - nl.Mp) * nl.K)
+	return s * (1 - s) * nl.K * nl.Scale
+}
+
+func (nl *NLLogistic) SecondDerivative(t float64) float64 {
+	s := logisticTransform((t - nl.Mp) * nl.K)
+	return s * (1 - s) * (1 - 2*s) * nl.K * nl.K * nl.Scale
+}
+
 // L = 1, k = 1, mp = 0
 func logisticTransform(t float64) float64 {
 	return 1 / (1 + math.Exp(-t))
@@ -249,7 +449,22 @@ func (nl *NLP3) Transform(t float64) float64 {
 }
 
 func (nl *NLP3) InvTransform(v float64) float64 {
-	return bsInv(v, nl)
+	return 0.5 - math.Sin(math.Asin(1-2*v)/3)
+}
+
+func (nl *NLP3) Derivative(t float64) float64 {
+	return 6 * t * (1 - t)
+}
+
+func (nl *NLP3) SecondDerivative(t float64) float64 {
+	return 6 - 12*t
+}
+
+func (nl *NLP3) Integral(a, b float64) float64 {
+	af := func(t float64) float64 {
+		return t * t * t * (1 - t/2)
+	}
+	return af(b) - af(a)
 }
 
 // NLP5 v = t^3 * (t*(6t-15) + 10)
@@ -263,13 +478,108 @@ func (nl *NLP5) InvTransform(v float64) float64 {
 	return bsInv(v, nl)
 }
 
-// NLCompound v = nl[0](nl[1](nl[2](...nl[n-1](t))))
+func (nl *NLP5) Derivative(t float64) float64 {
+	return 30 * t * t * (t*(t-2) + 1)
+}
+
+func (nl *NLP5) SecondDerivative(t float64) float64 {
+	return 60 * t * (t - 1) * (2*t - 1)
+}
+
+func (nl *NLP5) Integral(a, b float64) float64 {
+	af := func(t float64) float64 {
+		return t * t * t * t * (t*(t-3) + 2.5)
+	}
+	return af(b) - af(a)
+}
+
+// NLCompound v = nl[n-1](...nl[2](nl[1](nl[0](t))))
 type NLCompound struct {
 	Fs []NonLinear
 }
 
+// NewNLCompound flattens any nested compounds in fs, drops NLLinear, and fuses runs of power
+// functions into one, t^a then t^b becoming t^(ab), so each remaining layer costs a call.
 func NewNLCompound(fs []NonLinear) *NLCompound {
-	return &NLCompound{fs}
+	return &NLCompound{fusePowers(flattenCompound(nil, fs))}
+}
+
+// NewNLCompoundV is the variadic form of NewNLCompound - fs are applied left to right.
+func NewNLCompoundV(fs ...NonLinear) *NLCompound {
+	return NewNLCompound(fs)
+}
+
+// Then returns a new compound that applies f after the functions in nl.
+func (nl *NLCompound) Then(f NonLinear) *NLCompound {
+	fs := make([]NonLinear, len(nl.Fs), len(nl.Fs)+1)
+	copy(fs, nl.Fs)
+	return &NLCompound{fusePowers(flattenCompound(fs, []NonLinear{f}))}
+}
+
+func flattenCompound(dst, fs []NonLinear) []NonLinear {
+	for _, f := range fs {
+		if c, ok := f.(*NLCompound); ok {
+			dst = flattenCompound(dst, c.Fs)
+		} else {
+			dst = append(dst, f)
+		}
+	}
+	return dst
+}
+
+// power returns the exponent of f if it's a pure power function. Fast powers are left alone so as
+// not to change their Transform.
+func power(f NonLinear) (float64, bool) {
+	switch f := f.(type) {
+	case *NLLinear:
+		return 1, true
+	case *NLSquare:
+		return 2, true
+	case *NLCube:
+		return 3, true
+	case *NLPower:
+		return f.P, !f.Fast
+	}
+	return 0, false
+}
+
+// fusePowers replaces, in place, each run of power functions in fs with a single one. Runs of one
+// are left as is, other than NLLinear which is dropped unless it's all there is.
+func fusePowers(fs []NonLinear) []NonLinear {
+	n := len(fs)
+	res := fs[:0]
+	for i := 0; i < len(fs); {
+		p, ok := power(fs[i])
+		if !ok {
+			res = append(res, fs[i])
+			i++
+			continue
+		}
+		j := i + 1
+		for ; j < len(fs); j++ {
+			q, ok := power(fs[j])
+			if !ok {
+				break
+			}
+			p *= q
+		}
+		switch {
+		case p == 1:
+		case j-i == 1:
+			res = append(res, fs[i])
+		case p == 2:
+			res = append(res, &NLSquare{})
+		case p == 3:
+			res = append(res, &NLCube{})
+		default:
+			res = append(res, &NLPower{P: p})
+		}
+		i = j
+	}
+	if len(res) == 0 && n > 0 {
+		res = append(res, &NLLinear{})
+	}
+	return res
 }
 
 func (nl *NLCompound) Transform(t float64) float64 {
@@ -287,6 +597,25 @@ func (nl *NLCompound) InvTransform(v float64) float64 {
 	return v
 }
 
+func (nl *NLCompound) Derivative(t float64) float64 {
+	d := 1.0
+	for _, f := range nl.Fs {
+		d *= Derivative(f, t)
+		t = f.Transform(t)
+	}
+	return d
+}
+
+func (nl *NLCompound) SecondDerivative(t float64) float64 {
+	d1, d2 := 1.0, 0.0
+	for _, f := range nl.Fs {
+		a1, a2 := Derivative(f, t), SecondDerivative(f, t)
+		d1, d2 = a1*d1, a2*d1*d1+a1*d2
+		t = f.Transform(t)
+	}
+	return d2
+}
+
 // NLOmt v = 1-f(1-t)
 type NLOmt struct {
 	F NonLinear
@@ -307,65 +636,137 @@ func (nl *NLOmt) Transform(t float64) float64 {
 func (nl *NLOmt) InvTransform(v float64) float64 {
 	v = 1 - v
 	if v > 0 {
-		return 1 - nl.F.InvTransform(1-v)
+		return 1 - nl.F.InvTransform(v)
 	}
 	return 1
 }
 
-// NewStoppedNL uses linear interpolation between the supplied stops
+func (nl *NLOmt) Derivative(t float64) float64 {
+	return Derivative(nl.F, 1-t)
+}
+
+func (nl *NLOmt) SecondDerivative(t float64) float64 {
+	return -SecondDerivative(nl.F, 1-t)
+}
+
+func (nl *NLOmt) Integral(a, b float64) float64 {
+	return b - a - Integral(nl.F, 1-b, 1-a)
+}
+
+// NLStopped uses linear interpolation between the supplied stops, with t non-decreasing in [0,1].
+// Implicit stops at (0,0) and (1,1) are added unless the first stop is at t=0, or the last at t=1,
+// respectively. Stops sharing a t form a jump, and f is right-continuous: at the t of a jump the value
+// is that of the last stop there. At a stop Derivative returns the slope of the segment starting
+// there. Outside of [0,1] the end segments are extended.
 type NLStopped struct {
-	Stops [][]float64 // Pairs of t, v - both strictly ascending in [0,1]
+	Stops [][]float64 // Pairs of t, v with t non-decreasing in [0,1]
+
+	// Segment tables built by NewNLStopped, including any implicit stops
+	xs, vs, ms []float64
 }
 
+// NewNLStopped precomputes the segment slopes, so Stops shouldn't be modified afterwards - use
+// SetParams or construct a new NLStopped instead. The stops aren't checked, see NewNLStoppedChecked.
 func NewNLStopped(stops [][]float64) *NLStopped {
-	// Assumes valid stops
-	return &NLStopped{stops}
+	nl := &NLStopped{Stops: stops}
+	nl.xs, nl.vs, nl.ms = stopTables(stops)
+	return nl
+}
+
+// stopTables returns the stop positions and values, with any implicit end stops added, and the slope
+// of each segment.
+func stopTables(stops [][]float64) ([]float64, []float64, []float64) {
+	ns := len(stops)
+	xs, vs := make([]float64, 0, ns+2), make([]float64, 0, ns+2)
+	if ns == 0 || stops[0][0] > 0 {
+		xs, vs = append(xs, 0), append(vs, 0)
+	}
+	for _, s := range stops {
+		xs, vs = append(xs, s[0]), append(vs, s[1])
+	}
+	if ns == 0 || stops[ns-1][0] < 1 {
+		xs, vs = append(xs, 1), append(vs, 1)
+	}
+	ms := make([]float64, len(xs)-1)
+	for i := range ms {
+		if dt := xs[i+1] - xs[i]; dt != 0 {
+			ms[i] = (vs[i+1] - vs[i]) / dt
+		}
+	}
+	return xs, vs, ms
+}
+
+// Stop is a single t, v pair for NLStopped.
+type Stop struct {
+	T, V float64
+}
+
+// NewNLStoppedStops is NewNLStopped taking typed stops.
+func NewNLStoppedStops(stops []Stop) *NLStopped {
+	ss := make([][]float64, len(stops))
+	for i, s := range stops {
+		ss[i] = []float64{s.T, s.V}
+	}
+	return NewNLStopped(ss)
+}
+
+// TypedStops returns a copy of the stops as a slice of Stop.
+func (nl *NLStopped) TypedStops() []Stop {
+	stops := make([]Stop, len(nl.Stops))
+	for i, s := range nl.Stops {
+		stops[i] = Stop{s[0], s[1]}
+	}
+	return stops
 }
 
 func (nl *NLStopped) Transform(t float64) float64 {
-	t0, v0 := 0.0, 0.0
-	ns := len(nl.Stops)
-	var i int
-	for i = 0; i < ns; i++ {
-		if nl.Stops[i][0] > t {
-			if i > 1 {
-				t0 = nl.Stops[i-1][0]
-				v0 = nl.Stops[i-1][1]
-			}
-			break
+	i, xs, vs, ms := nl.segment(t)
+	if xs[i] == xs[i+1] {
+		// A jump at an end
+		if t < xs[i] {
+			return vs[i]
 		}
+		return vs[i+1]
 	}
-	if i == ns {
-		t0 = nl.Stops[ns-1][0]
-		v0 = nl.Stops[ns-1][1]
+	return vs[i] + (t-xs[i])*ms[i]
+}
+
+// segment returns the index of the segment containing t, and the segment tables. Segment i runs from
+// xs[i] up to but excluding xs[i+1], other than the first and last which extend beyond [0,1]. A zero
+// width segment is only returned for t at or after a jump at the last stop, or before one at the first.
+func (nl *NLStopped) segment(t float64) (int, []float64, []float64, []float64) {
+	xs, vs, ms := nl.xs, nl.vs, nl.ms
+	if xs == nil {
+		// Not made by NewNLStopped
+		xs, vs, ms = stopTables(nl.Stops)
 	}
-	t1, v1 := 1.0, 1.0
-	if i < ns {
-		t1 = nl.Stops[i][0]
-		v1 = nl.Stops[i][1]
+	// Binary search for the first stop after t, the segment is the one ending there
+	lo, hi := 1, len(xs)-1
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if xs[m] > t {
+			hi = m
+		} else {
+			lo = m + 1
+		}
 	}
-	dt := t1 - t0
-	t = (t - t0) / dt
-	return (1-t)*v0 + t*v1
+	return lo - 1, xs, vs, ms
 }
 
 func (nl *NLStopped) InvTransform(v float64) float64 {
 	return bsInv(v, nl)
 }
 
-// Numerical method to find inverse
-func bsInv(v float64, f NonLinear) float64 {
-	n := 16
-	t := 0.5
-	s := 0.25
+func (nl *NLStopped) Derivative(t float64) float64 {
+	i, _, _, ms := nl.segment(t)
+	return ms[i]
+}
 
-	for ; n > 0; n-- {
-		if f.Transform(t) > v {
-			t -= s
-		} else {
-			t += s
-		}
-		s /= 2
-	}
-	return t
+func (nl *NLStopped) SecondDerivative(t float64) float64 {
+	return 0
+}
+
+// Numerical method to find inverse - see SolveMonotone.
+func bsInv(v float64, f NonLinear) float64 {
+	return SolveMonotone(f, v)
 }
@@ -0,0 +1,208 @@
+package nonlinear
+
+import (
+	"math"
+	"sort"
+)
+
+// nelderMead minimizes fn starting from x0 using the Nelder-Mead simplex method. step is the initial
+// size of the simplex. Returns the best point found and its value.
+func nelderMead(fn func([]float64) float64, x0 []float64, step float64, iters int) ([]float64, float64) {
+	n := len(x0)
+	type vertex struct {
+		x []float64
+		f float64
+	}
+	simplex := make([]vertex, n+1)
+	for i := range simplex {
+		x := append([]float64(nil), x0...)
+		if i > 0 {
+			x[i-1] += step
+		}
+		simplex[i] = vertex{x, fn(x)}
+	}
+
+	point := func(c, x []float64, a float64) []float64 {
+		p := make([]float64, n)
+		for i := range p {
+			p[i] = c[i] + a*(x[i]-c[i])
+		}
+		return p
+	}
+
+	for it := 0; it < iters; it++ {
+		sort.Slice(simplex, func(i, j int) bool { return simplex[i].f < simplex[j].f })
+		if math.Abs(simplex[n].f-simplex[0].f) <= 1e-15*(math.Abs(simplex[0].f)+1e-300) {
+			break
+		}
+
+		// Centroid of all but the worst
+		c := make([]float64, n)
+		for _, v := range simplex[:n] {
+			for i := range c {
+				c[i] += v.x[i] / float64(n)
+			}
+		}
+
+		worst := simplex[n]
+		r := point(c, worst.x, -1)
+		fr := fn(r)
+		switch {
+		case fr < simplex[0].f:
+			e := point(c, worst.x, -2)
+			if fe := fn(e); fe < fr {
+				simplex[n] = vertex{e, fe}
+			} else {
+				simplex[n] = vertex{r, fr}
+			}
+		case fr < simplex[n-1].f:
+			simplex[n] = vertex{r, fr}
+		default:
+			k := point(c, worst.x, 0.5)
+			if fr < worst.f {
+				k = point(c, r, 0.5)
+			}
+			if fk := fn(k); fk < math.Min(fr, worst.f) {
+				simplex[n] = vertex{k, fk}
+				continue
+			}
+			// Shrink towards the best
+			for i := 1; i <= n; i++ {
+				x := point(simplex[0].x, simplex[i].x, 0.5)
+				simplex[i] = vertex{x, fn(x)}
+			}
+		}
+	}
+	sort.Slice(simplex, func(i, j int) bool { return simplex[i].f < simplex[j].f })
+	return simplex[0].x, simplex[0].f
+}
+
+// levenbergMarquardt minimizes the sum of the squares of the m residuals computed by res, starting
+// from p0. res fills r and returns false if p is outside the function's domain, in which case the step
+// is rejected. The Jacobian is found by finite differences. Returns the best point found and its sum
+// of squares.
+func levenbergMarquardt(res func(p, r []float64) bool, p0 []float64, m, iters int) ([]float64, float64) {
+	n := len(p0)
+	sumSq := func(r []float64) float64 {
+		s := 0.0
+		for _, v := range r {
+			s += v * v
+		}
+		return s
+	}
+	p := append([]float64(nil), p0...)
+	r := make([]float64, m)
+	if !res(p, r) {
+		return p, math.Inf(1)
+	}
+	cost := sumSq(r)
+
+	jac := make([][]float64, n) // jac[j][i] = dr_i/dp_j
+	for j := range jac {
+		jac[j] = make([]float64, m)
+	}
+	a := make([][]float64, n)
+	for j := range a {
+		a[j] = make([]float64, n)
+	}
+	g, q, rq := make([]float64, n), make([]float64, n), make([]float64, m)
+	lambda := 1e-3
+	for it := 0; it < iters && cost > 0; it++ {
+		// Forward differences, or backward ones at the edge of the domain
+		for j := range p {
+			h := 1e-7 * (1 + math.Abs(p[j]))
+			copy(q, p)
+			q[j] += h
+			if !res(q, rq) {
+				q[j] = p[j] - h
+				h = -h
+				if !res(q, rq) {
+					for i := range jac[j] {
+						jac[j][i] = 0
+					}
+					continue
+				}
+			}
+			for i := range rq {
+				jac[j][i] = (rq[i] - r[i]) / h
+			}
+		}
+		for j := range a {
+			g[j] = 0
+			for i := range r {
+				g[j] -= jac[j][i] * r[i]
+			}
+			for k := range a[j] {
+				s := 0.0
+				for i := range r {
+					s += jac[j][i] * jac[k][i]
+				}
+				a[j][k] = s
+			}
+		}
+
+		// Damp until a step improves the cost
+		improved := false
+		for lambda < 1e12 {
+			d := make([][]float64, n)
+			for j := range d {
+				d[j] = append([]float64(nil), a[j]...)
+				d[j][j] += lambda * math.Max(a[j][j], 1e-12)
+			}
+			step := append([]float64(nil), g...)
+			if solveLinear(d, step) {
+				for j := range q {
+					q[j] = p[j] + step[j]
+				}
+				if res(q, rq) {
+					if c := sumSq(rq); c < cost {
+						improved = cost-c > 1e-15*cost
+						copy(p, q)
+						copy(r, rq)
+						cost = c
+						lambda = math.Max(lambda/10, 1e-12)
+						break
+					}
+				}
+			}
+			lambda *= 10
+		}
+		if !improved {
+			break
+		}
+	}
+	return p, cost
+}
+
+// solveLinear solves a x = b in place by Gaussian elimination with partial pivoting, leaving x in b.
+// Returns false if a is singular.
+func solveLinear(a [][]float64, b []float64) bool {
+	n := len(b)
+	for c := 0; c < n; c++ {
+		piv := c
+		for r := c + 1; r < n; r++ {
+			if math.Abs(a[r][c]) > math.Abs(a[piv][c]) {
+				piv = r
+			}
+		}
+		if a[piv][c] == 0 {
+			return false
+		}
+		a[c], a[piv] = a[piv], a[c]
+		b[c], b[piv] = b[piv], b[c]
+		for r := c + 1; r < n; r++ {
+			f := a[r][c] / a[c][c]
+			for k := c; k < n; k++ {
+				a[r][k] -= f * a[c][k]
+			}
+			b[r] -= f * b[c]
+		}
+	}
+	for c := n - 1; c >= 0; c-- {
+		for k := c + 1; k < n; k++ {
+			b[c] -= a[c][k] * b[k]
+		}
+		b[c] /= a[c][c]
+	}
+	return true
+}
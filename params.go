@@ -0,0 +1,216 @@
+package nonlinear
+
+import "reflect"
+
+// Parameterized is implemented by curves with numeric parameters. Params returns them in registry
+// order (see Lookup for their names) and SetParams replaces them, recomputing any cached values such
+// as scale factors, so a curve can be tuned in place. SetParams validates p as the registry does and
+// leaves the curve unchanged on error.
+type Parameterized interface {
+	NonLinear
+	Params() []float64
+	SetParams(p ...float64) error
+}
+
+// setParams rebuilds dst from its spec with the parameters p and assigns the result to *dst.
+func setParams(dst specifier, p []float64) error {
+	s := dst.spec()
+	f, err := NewWith(s.Name, p, s.Curves...)
+	if err != nil {
+		return err
+	}
+	reflect.ValueOf(dst).Elem().Set(reflect.ValueOf(f).Elem())
+	return nil
+}
+
+func (nl *NLExponential) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLExponential) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLLogarithmic) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLLogarithmic) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLLame) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLLame) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLGauss) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLGauss) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLLogistic) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLLogistic) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLStopped) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLStopped) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLSpline) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLSpline) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLPolynomial) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLPolynomial) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLSpring) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLSpring) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLConditional) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLConditional) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLInOut) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLInOut) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLSoftClamp) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLSoftClamp) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLConstantSpeed) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLConstantSpeed) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLInverse) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLInverse) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLCubicBezier) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLCubicBezier) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLSteps) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLSteps) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLPower) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLPower) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLBack) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLBack) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLElastic) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLElastic) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLWindow) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLWindow) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLTable) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLTable) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLCached) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLCached) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLInvTable) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLInvTable) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
+
+func (nl *NLDomain) Params() []float64 {
+	return nl.spec().Params
+}
+
+func (nl *NLDomain) SetParams(p ...float64) error {
+	return setParams(nl, p)
+}
@@ -0,0 +1,147 @@
+package nonlinear
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Parse constructs a curve from an expression such as "omt(compound(square, logistic(12,0.5)))" or
+// "stops(0.25:0.1, 0.75:0.9)". Names are looked up in the registry. The arguments of a curve are
+// numbers, t:v pairs (which are equivalent to two numbers) and other curves, in any order; numbers
+// become the parameters and curves the wrapped curves, each in the order given. The parentheses may
+// be omitted when there are no arguments.
+func Parse(s string) (NonLinear, error) {
+	p := &parser{s: s}
+	f, err := p.curve()
+	if err != nil {
+		return nil, err
+	}
+	p.skip()
+	if p.pos < len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+	return f, nil
+}
+
+// ParseError records the offset in the expression at which parsing failed.
+type ParseError struct {
+	Offset int
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("nonlinear: parse error at offset %d: %s", e.Offset, strings.TrimPrefix(e.Err.Error(), "nonlinear: "))
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &ParseError{p.pos, fmt.Errorf(format, args...)}
+}
+
+func (p *parser) skip() {
+	for p.pos < len(p.s) && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *parser) peek() byte {
+	p.skip()
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+func isIdent(c byte, first bool) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || !first && (c >= '0' && c <= '9' || c == '-')
+}
+
+func (p *parser) curve() (NonLinear, error) {
+	p.skip()
+	start := p.pos
+	for p.pos < len(p.s) && isIdent(p.s[p.pos], p.pos == start) {
+		p.pos++
+	}
+	if p.pos == start {
+		return nil, p.errorf("expected curve name")
+	}
+	name := strings.ToLower(p.s[start:p.pos])
+
+	var params []float64
+	var curves []NonLinear
+	if p.peek() == '(' {
+		p.pos++
+		if p.peek() == ')' {
+			p.pos++
+		} else {
+			for {
+				c := p.peek()
+				if isIdent(c, true) {
+					f, err := p.curve()
+					if err != nil {
+						return nil, err
+					}
+					curves = append(curves, f)
+				} else {
+					v, err := p.number()
+					if err != nil {
+						return nil, err
+					}
+					params = append(params, v)
+					if p.peek() == ':' {
+						p.pos++
+						v, err = p.number()
+						if err != nil {
+							return nil, err
+						}
+						params = append(params, v)
+					}
+				}
+				c = p.peek()
+				if c == ')' {
+					p.pos++
+					break
+				}
+				if c != ',' {
+					return nil, p.errorf("expected ',' or ')'")
+				}
+				p.pos++
+			}
+		}
+	}
+
+	f, err := NewWith(name, params, curves...)
+	if err != nil {
+		return nil, &ParseError{start, err}
+	}
+	return f, nil
+}
+
+func (p *parser) number() (float64, error) {
+	p.skip()
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if c >= '0' && c <= '9' || c == '.' || c == '+' || c == '-' || c == 'e' || c == 'E' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	v, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("expected number")
+	}
+	return v, nil
+}
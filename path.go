@@ -0,0 +1,21 @@
+package nonlinear
+
+import g2d "github.com/jphsd/graphics2d"
+
+// CurvePath returns the graph of f, from t=0 to t=1 in n steps, as a path. If xfm is not nil, it's
+// applied to the path, e.g. to map the unit square into an image.
+func CurvePath(f NonLinear, n int, xfm g2d.Transform) *g2d.Path {
+	if n < 1 {
+		n = 1
+	}
+	path := g2d.NewPath([]float64{0, f.Transform(0)})
+	dt := 1.0 / float64(n)
+	for i := 1; i <= n; i++ {
+		t := float64(i) * dt
+		path.AddSteps([][]float64{{t, f.Transform(t)}})
+	}
+	if xfm != nil {
+		path = path.Transform(xfm)
+	}
+	return path
+}
@@ -0,0 +1,152 @@
+package nonlinear
+
+import (
+	"fmt"
+	"math"
+)
+
+// NLPolynomial v = c[0] + c[1]*t + c[2]*t^2 + ... The coefficients aren't constrained, so the curve
+// need not pass through (0,0) and (1,1) nor be monotonic, see Constrain. InvTransform assumes it's
+// monotonic in [0,1].
+type NLPolynomial struct {
+	C []float64 // Coefficients, lowest power first
+}
+
+func NewNLPolynomial(c ...float64) *NLPolynomial {
+	return &NLPolynomial{c}
+}
+
+func (nl *NLPolynomial) Transform(t float64) float64 {
+	v := 0.0
+	for i := len(nl.C) - 1; i >= 0; i-- {
+		v = v*t + nl.C[i]
+	}
+	return v
+}
+
+func (nl *NLPolynomial) InvTransform(v float64) float64 {
+	return bsInv(v, nl)
+}
+
+func (nl *NLPolynomial) Derivative(t float64) float64 {
+	d := 0.0
+	for i := len(nl.C) - 1; i > 0; i-- {
+		d = d*t + float64(i)*nl.C[i]
+	}
+	return d
+}
+
+func (nl *NLPolynomial) SecondDerivative(t float64) float64 {
+	d := 0.0
+	for i := len(nl.C) - 1; i > 1; i-- {
+		d = d*t + float64(i*(i-1))*nl.C[i]
+	}
+	return d
+}
+
+func (nl *NLPolynomial) Integral(a, b float64) float64 {
+	af := func(t float64) float64 {
+		v := 0.0
+		for i := len(nl.C) - 1; i >= 0; i-- {
+			v = v*t + nl.C[i]/float64(i+1)
+		}
+		return v * t
+	}
+	return af(b) - af(a)
+}
+
+// Constraints collects conditions on a curve's value and derivatives at given points, from which
+// Polynomial solves for the curve, e.g. P3 is
+//
+//	Constrain().Slope(0, 0).Slope(1, 0).Polynomial()
+//
+// As with NLStopped, the values at 0 and 1 are implicitly 0 and 1 unless constrained otherwise.
+type Constraints struct {
+	cs []constraint
+}
+
+// constraint requires the order'th derivative of the curve to be v at t.
+type constraint struct {
+	order int
+	t, v  float64
+}
+
+// Constrain starts an empty set of constraints.
+func Constrain() *Constraints {
+	return &Constraints{}
+}
+
+// Value requires the curve to pass through (t, v).
+func (c *Constraints) Value(t, v float64) *Constraints {
+	c.cs = append(c.cs, constraint{0, t, v})
+	return c
+}
+
+// Slope requires dv/dt to be d at t.
+func (c *Constraints) Slope(t, d float64) *Constraints {
+	c.cs = append(c.cs, constraint{1, t, d})
+	return c
+}
+
+// SecondDerivative requires d2v/dt2 to be d2 at t.
+func (c *Constraints) SecondDerivative(t, d2 float64) *Constraints {
+	c.cs = append(c.cs, constraint{2, t, d2})
+	return c
+}
+
+// NaturalEnds requires the second derivative to be zero at 0 and 1.
+func (c *Constraints) NaturalEnds() *Constraints {
+	return c.SecondDerivative(0, 0).SecondDerivative(1, 0)
+}
+
+// Polynomial returns the polynomial of the lowest degree satisfying the constraints, one less than
+// their number, including the implicit end values. It's an error for the constraints to contradict
+// each other or to not determine the polynomial uniquely, e.g. when two are the same.
+func (c *Constraints) Polynomial() (*NLPolynomial, error) {
+	cs := c.cs
+	has := func(t float64) bool {
+		for _, x := range c.cs {
+			if x.order == 0 && x.t == t {
+				return true
+			}
+		}
+		return false
+	}
+	if !has(0) {
+		cs = append(cs, constraint{0, 0, 0})
+	}
+	if !has(1) {
+		cs = append(cs, constraint{0, 1, 1})
+	}
+
+	n := len(cs)
+	a := make([][]float64, n)
+	b := make([]float64, n)
+	for r, x := range cs {
+		if !isFinite(x.t) {
+			return nil, invalidParam(fmt.Sprintf("constraint[%d].t", r), x.t, "finite")
+		}
+		if !isFinite(x.v) {
+			return nil, invalidParam(fmt.Sprintf("constraint[%d].v", r), x.v, "finite")
+		}
+		// Row of the order'th derivative of each power of t
+		a[r] = make([]float64, n)
+		for i := x.order; i < n; i++ {
+			k := 1.0
+			for j := 0; j < x.order; j++ {
+				k *= float64(i - j)
+			}
+			a[r][i] = k * math.Pow(x.t, float64(i-x.order))
+		}
+		b[r] = x.v
+	}
+	if !solveLinear(a, b) {
+		return nil, fmt.Errorf("nonlinear: constraints don't determine a unique polynomial")
+	}
+	for _, v := range b {
+		if !isFinite(v) {
+			return nil, fmt.Errorf("nonlinear: constraints don't determine a unique polynomial")
+		}
+	}
+	return NewNLPolynomial(b...), nil
+}
@@ -0,0 +1,18 @@
+package nonlinear
+
+// Shared instances of the curves without parameters, safe to use concurrently and as defaults as long
+// as they aren't modified. In particular SineInOut.Fast mustn't be set, as that would change every
+// user of SineInOut - use NewNLSinFast instead.
+var (
+	Linear       = &NLLinear{}
+	Square       = &NLSquare{}
+	Cube         = &NLCube{}
+	SineIn       = &NLSin2{}
+	SineOut      = &NLSin1{}
+	SineInOut    = &NLSin{}
+	CircleIn     = &NLCircle1{}
+	CircleOut    = &NLCircle2{}
+	Catenary     = &NLCatenary{}
+	SmoothStep   = &NLP3{}
+	SmootherStep = &NLP5{}
+)
@@ -0,0 +1,61 @@
+package nonlinear
+
+import "math"
+
+// Quaternion represents a rotation as W + Xi + Yj + Zk. Rotations should be unit quaternions.
+type Quaternion struct {
+	W, X, Y, Z float64
+}
+
+func (q Quaternion) dot(r Quaternion) float64 {
+	return q.W*r.W + q.X*r.X + q.Y*r.Y + q.Z*r.Z
+}
+
+func (q Quaternion) scale(s float64) Quaternion {
+	return Quaternion{q.W * s, q.X * s, q.Y * s, q.Z * s}
+}
+
+func (q Quaternion) add(r Quaternion) Quaternion {
+	return Quaternion{q.W + r.W, q.X + r.X, q.Y + r.Y, q.Z + r.Z}
+}
+
+// Normalize returns q scaled to unit length.
+func (q Quaternion) Normalize() Quaternion {
+	l := math.Sqrt(q.dot(q))
+	if l == 0 {
+		return Quaternion{W: 1}
+	}
+	return q.scale(1 / l)
+}
+
+// shortest returns b or -b, whichever is nearer to a. Both represent the same rotation.
+func shortest(a, b Quaternion) (Quaternion, float64) {
+	d := a.dot(b)
+	if d < 0 {
+		return b.scale(-1), -d
+	}
+	return b, d
+}
+
+// SlerpQuaternion interpolates between the rotations a and b along the shorter arc, at a constant
+// angular rate for linear f, with the fraction of the rotation given by f at t (clamped to [0,1]).
+func SlerpQuaternion(t float64, a, b Quaternion, f NonLinear) Quaternion {
+	s := NLerp(t, 0, 1, f)
+	b, d := shortest(a, b)
+	if d > 0.9995 {
+		// Nearly identical, avoid dividing by sin(theta) ~ 0
+		return a.scale(1 - s).add(b.scale(s)).Normalize()
+	}
+	theta := math.Acos(math.Min(1, d))
+	sn := math.Sin(theta)
+	return a.scale(math.Sin((1-s)*theta) / sn).add(b.scale(math.Sin(s*theta) / sn))
+}
+
+// NlerpQuaternion interpolates between the rotations a and b along the shorter arc by normalized
+// linear interpolation, with the fraction given by f at t (clamped to [0,1]). It's cheaper than
+// SlerpQuaternion but the angular rate isn't constant.
+func NlerpQuaternion(t float64, a, b Quaternion, f NonLinear) Quaternion {
+	s := NLerp(t, 0, 1, f)
+	b, _ = shortest(a, b)
+	return a.scale(1 - s).add(b.scale(s)).Normalize()
+}
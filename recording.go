@@ -0,0 +1,71 @@
+package nonlinear
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+const (
+	recordingSamples = 129 // Samples the recording is resampled to
+	recordingPasses  = 3   // Passes of the [1 2 1] smoothing filter
+	recordingStep    = 4   // Samples per knot
+)
+
+// FromRecording turns a recorded gesture, such as a drag sampled at times with the given values, into
+// a curve. The recording is sorted by time, resampled evenly, normalized so that it starts at (0,0)
+// and ends at (1,1), smoothed to remove jitter and then fitted with an NLSpline through 33 knots.
+// Overshoot in the recording is kept. Non-finite samples are ignored.
+func FromRecording(times, values []float64) (*NLSpline, error) {
+	if len(times) != len(values) {
+		return nil, fmt.Errorf("nonlinear: FromRecording has %d times but %d values", len(times), len(values))
+	}
+	pts := make([]Stop, 0, len(times))
+	for i, t := range times {
+		if isFinite(t) && isFinite(values[i]) {
+			pts = append(pts, Stop{t, values[i]})
+		}
+	}
+	slices.SortStableFunc(pts, func(a, b Stop) int { return cmp.Compare(a.T, b.T) })
+	n := len(pts)
+	if n < 2 || pts[0].T == pts[n-1].T {
+		return nil, fmt.Errorf("nonlinear: FromRecording needs samples at 2 or more distinct times")
+	}
+	t0, dt := pts[0].T, pts[n-1].T-pts[0].T
+	v0, dv := pts[0].V, pts[n-1].V-pts[0].V
+	if dv == 0 {
+		return nil, fmt.Errorf("nonlinear: FromRecording ends where it starts")
+	}
+
+	// Resample by linear interpolation, a sample at the time of a jump taking the later value
+	vs := make([]float64, recordingSamples)
+	j := 0
+	for i := range vs {
+		t := t0 + dt*float64(i)/(recordingSamples-1)
+		for j < n-2 && pts[j+1].T <= t {
+			j++
+		}
+		a, b := pts[j], pts[j+1]
+		v := b.V
+		if t < b.T {
+			v = a.V + (b.V-a.V)*(t-a.T)/(b.T-a.T)
+		}
+		vs[i] = (v - v0) / dv
+	}
+	vs[0], vs[len(vs)-1] = 0, 1
+
+	// Smooth the interior, leaving the end points in place
+	tmp := make([]float64, len(vs))
+	for p := 0; p < recordingPasses; p++ {
+		copy(tmp, vs)
+		for i := 1; i < len(vs)-1; i++ {
+			vs[i] = (tmp[i-1] + 2*tmp[i] + tmp[i+1]) / 4
+		}
+	}
+
+	knots := make([][]float64, 0, recordingSamples/recordingStep+1)
+	for i := 0; i < len(vs); i += recordingStep {
+		knots = append(knots, []float64{float64(i) / (recordingSamples - 1), vs[i]})
+	}
+	return NewNLSpline(knots), nil
+}
@@ -0,0 +1,385 @@
+package nonlinear
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Factory constructs a curve from its numeric parameters and, for combinators, the curves it wraps.
+type Factory func(params []float64, curves []NonLinear) (NonLinear, error)
+
+// CurveInfo describes a registered curve.
+type CurveInfo struct {
+	Name      string
+	Params    []string // Names of the numeric parameters
+	VarParams bool     // Params may be repeated any number of times (at least once)
+	Curves    int      // Number of curve arguments, -1 for any number
+	Doc       string
+}
+
+type registration struct {
+	info CurveInfo
+	fn   Factory
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]registration{}
+)
+
+// Register makes a curve available by name to New, NewWith and ListCurves. It panics if the name is
+// already registered.
+func Register(info CurveInfo, fn Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, dup := registry[info.Name]; dup {
+		panic("nonlinear: Register called twice for " + info.Name)
+	}
+	registry[info.Name] = registration{info, fn}
+}
+
+// Lookup returns the description of the named curve.
+func Lookup(name string) (CurveInfo, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	r, ok := registry[name]
+	return r.info, ok
+}
+
+// ListCurves returns the descriptions of all registered curves, sorted by name.
+func ListCurves() []CurveInfo {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	res := make([]CurveInfo, 0, len(registry))
+	for _, r := range registry {
+		res = append(res, r.info)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+// New constructs the named curve from params, e.g. New("logistic", 12, 0.5).
+func New(name string, params ...float64) (NonLinear, error) {
+	return NewWith(name, params)
+}
+
+// NewWith constructs the named curve from params and the curves it wraps, e.g.
+// NewWith("omt", nil, &NLSquare{}).
+func NewWith(name string, params []float64, curves ...NonLinear) (NonLinear, error) {
+	registryMu.RLock()
+	r, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("nonlinear: unknown curve %q", name)
+	}
+	info := r.info
+	np := len(info.Params)
+	if info.VarParams {
+		if len(params) == 0 || len(params)%np != 0 {
+			return nil, fmt.Errorf("nonlinear: %s takes a multiple of %d parameters, got %d", name, np, len(params))
+		}
+	} else if len(params) != np {
+		return nil, fmt.Errorf("nonlinear: %s takes %d parameters, got %d", name, np, len(params))
+	}
+	if info.Curves >= 0 && len(curves) != info.Curves {
+		return nil, fmt.Errorf("nonlinear: %s takes %d curves, got %d", name, info.Curves, len(curves))
+	}
+	return r.fn(params, curves)
+}
+
+// simple registers a curve with no parameters.
+// isInt returns true if v is an integer in [lo,hi].
+func isInt(v, lo, hi float64) bool {
+	return v >= lo && v <= hi && math.Trunc(v) == v
+}
+
+func simple(name, doc string, fn func() NonLinear) {
+	Register(CurveInfo{Name: name, Doc: doc}, func([]float64, []NonLinear) (NonLinear, error) {
+		return fn(), nil
+	})
+}
+
+func init() {
+	simple("linear", "v = t", func() NonLinear { return &NLLinear{} })
+	simple("square", "v = t^2", func() NonLinear { return &NLSquare{} })
+	simple("cube", "v = t^3", func() NonLinear { return &NLCube{} })
+	simple("sin", "v = sin(t) with t mapped to [-Pi/2,Pi/2]", func() NonLinear { return &NLSin{} })
+	simple("sin_fast", "sin using an approximation of sin", func() NonLinear { return NewNLSinFast() })
+	simple("sin1", "v = sin(t) with t mapped to [0,Pi/2]", func() NonLinear { return &NLSin1{} })
+	simple("sin2", "v = sin(t) with t mapped to [-Pi/2,0]", func() NonLinear { return &NLSin2{} })
+	simple("circle1", "v = 1 - sqrt(1-t^2)", func() NonLinear { return &NLCircle1{} })
+	simple("circle2", "v = sqrt(2t-t^2)", func() NonLinear { return &NLCircle2{} })
+	simple("catenary", "v = cosh(t)", func() NonLinear { return &NLCatenary{} })
+	simple("p3", "v = t^2 * (3-2t)", func() NonLinear { return &NLP3{} })
+	simple("p5", "v = t^3 * (t*(6t-15) + 10)", func() NonLinear { return &NLP5{} })
+
+	Register(CurveInfo{Name: "exponential", Params: []string{"k"}, Doc: "v = (exp(t*k) - 1) * scale"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			return NewNLExponentialChecked(p[0])
+		})
+	Register(CurveInfo{Name: "exponential_fast", Params: []string{"k"}, Doc: "exponential using an approximation of exp"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			nl, err := NewNLExponentialChecked(p[0])
+			if err != nil {
+				return nil, err
+			}
+			nl.setFast()
+			if !isFinite(nl.Scale) || nl.Scale == 0 {
+				return nil, invalidParam("k", p[0], "with a finite, non-zero scale")
+			}
+			return nl, nil
+		})
+	Register(CurveInfo{Name: "logarithmic", Params: []string{"k"}, Doc: "v = log(1+t*k) * scale"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			return NewNLLogarithmicChecked(p[0])
+		})
+	Register(CurveInfo{Name: "lame", Params: []string{"n", "m"}, Doc: "v = 1 - (1-t^n)^1/m"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			return NewNLLameChecked(p[0], p[1])
+		})
+	Register(CurveInfo{Name: "gauss", Params: []string{"k"}, Doc: "v = gauss(t, k)"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			return NewNLGaussChecked(p[0])
+		})
+	Register(CurveInfo{Name: "gauss_fast", Params: []string{"k"}, Doc: "gauss using an approximation of exp"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			nl, err := NewNLGaussChecked(p[0])
+			if err != nil {
+				return nil, err
+			}
+			nl.setFast()
+			if !isFinite(nl.Scale) || nl.Scale == 0 {
+				return nil, invalidParam("k", p[0], "with a finite, non-zero scale")
+			}
+			return nl, nil
+		})
+	Register(CurveInfo{Name: "logistic", Params: []string{"k", "mp"}, Doc: "v = logistic(t, k, mp)"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			return NewNLLogisticChecked(p[0], p[1])
+		})
+	Register(CurveInfo{Name: "logistic_fast", Params: []string{"k", "mp"}, Doc: "logistic using an approximation of exp"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			nl, err := NewNLLogisticChecked(p[0], p[1])
+			if err != nil {
+				return nil, err
+			}
+			nl.setFast()
+			return nl, nil
+		})
+	Register(CurveInfo{Name: "stops", Params: []string{"t", "v"}, VarParams: true, Doc: "linear interpolation between stops"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			// Values aren't restricted, as CSS linear() may overshoot
+			stops := make([][]float64, len(p)/2)
+			pt := 0.0
+			for i := range stops {
+				t, v := p[2*i], p[2*i+1]
+				if !(t >= pt && t <= 1) {
+					return nil, invalidParam(fmt.Sprintf("t[%d]", i), t, fmt.Sprintf("in [%g,1]", pt))
+				}
+				if !isFinite(v) {
+					return nil, invalidParam(fmt.Sprintf("v[%d]", i), v, "finite")
+				}
+				stops[i] = []float64{t, v}
+				pt = t
+			}
+			return NewNLStopped(stops), nil
+		})
+	Register(CurveInfo{Name: "spline", Params: []string{"t", "v"}, VarParams: true, Doc: "monotone cubic spline through knots"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			knots := make([][]float64, len(p)/2)
+			pt := math.Inf(-1)
+			for i := range knots {
+				t, v := p[2*i], p[2*i+1]
+				if !(t > pt && t >= 0 && t <= 1) {
+					return nil, invalidParam(fmt.Sprintf("t[%d]", i), t, fmt.Sprintf("in (%g,1]", math.Max(pt, 0)))
+				}
+				if !isFinite(v) {
+					return nil, invalidParam(fmt.Sprintf("v[%d]", i), v, "finite")
+				}
+				knots[i] = []float64{t, v}
+				pt = t
+			}
+			return NewNLSpline(knots), nil
+		})
+	Register(CurveInfo{Name: "polynomial", Params: []string{"c"}, VarParams: true, Doc: "v = c0 + c1*t + c2*t^2 + ..."},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			for i, c := range p {
+				if !isFinite(c) {
+					return nil, invalidParam(fmt.Sprintf("c[%d]", i), c, "finite")
+				}
+			}
+			return NewNLPolynomial(append([]float64(nil), p...)...), nil
+		})
+
+	Register(CurveInfo{Name: "cubicbezier", Params: []string{"x1", "y1", "x2", "y2"}, Doc: "CSS cubic-bezier(x1, y1, x2, y2)"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			if !(p[0] >= 0 && p[0] <= 1) {
+				return nil, invalidParam("x1", p[0], "in [0,1]")
+			}
+			if !(p[2] >= 0 && p[2] <= 1) {
+				return nil, invalidParam("x2", p[2], "in [0,1]")
+			}
+			return NewNLCubicBezier(p[0], p[1], p[2], p[3]), nil
+		})
+	Register(CurveInfo{Name: "steps", Params: []string{"n", "position"}, Doc: "CSS steps(n, position), position 0 = jump-end, 1 = jump-start, 2 = jump-none, 3 = jump-both"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			pos := StepPosition(p[1])
+			if !(p[1] >= 0 && p[1] <= 3) || float64(pos) != p[1] {
+				return nil, invalidParam("position", p[1], "0, 1, 2 or 3")
+			}
+			min := 1.0
+			if pos == JumpNone {
+				min = 2
+			}
+			if !isInt(p[0], min, math.MaxInt32) {
+				return nil, invalidParam("n", p[0], fmt.Sprintf("an integer in [%g,%d]", min, math.MaxInt32))
+			}
+			return NewNLSteps(int(p[0]), pos), nil
+		})
+
+	Register(CurveInfo{Name: "power", Params: []string{"p"}, Doc: "v = t^p"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			if !(p[0] > 0) || !isFinite(p[0]) {
+				return nil, invalidParam("p", p[0], "> 0")
+			}
+			return NewNLPower(p[0]), nil
+		})
+	Register(CurveInfo{Name: "power_fast", Params: []string{"p"}, Doc: "power using an approximation of pow"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			if !(p[0] > 0) || !isFinite(p[0]) {
+				return nil, invalidParam("p", p[0], "> 0")
+			}
+			return NewNLPowerFast(p[0]), nil
+		})
+	Register(CurveInfo{Name: "back", Params: []string{"c"}, Doc: "v = t^2 * ((c+1)t - c)"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			if !(p[0] >= 0) || !isFinite(p[0]) {
+				return nil, invalidParam("c", p[0], ">= 0")
+			}
+			return NewNLBack(p[0]), nil
+		})
+	Register(CurveInfo{Name: "elastic", Params: []string{"period"}, Doc: "v = -2^(10(t-1)) * sin((t-1-p/4) * 2Pi/p)"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			if !(p[0] > 0) || !isFinite(p[0]) {
+				return nil, invalidParam("period", p[0], "> 0")
+			}
+			return NewNLElastic(p[0]), nil
+		})
+	Register(CurveInfo{Name: "spring", Params: []string{"stiffness", "damping", "mass", "duration"}, Doc: "v = damped spring position after t*duration seconds"},
+		func(p []float64, _ []NonLinear) (NonLinear, error) {
+			if !(p[0] > 0) || !isFinite(p[0]) {
+				return nil, invalidParam("stiffness", p[0], "finite and > 0")
+			}
+			if !(p[1] >= 0) || !isFinite(p[1]) {
+				return nil, invalidParam("damping", p[1], "finite and >= 0")
+			}
+			if !(p[2] > 0) || !isFinite(p[2]) {
+				return nil, invalidParam("mass", p[2], "finite and > 0")
+			}
+			if !(p[3] > 0) || !isFinite(p[3]) {
+				return nil, invalidParam("duration", p[3], "finite and > 0")
+			}
+			return NewNLSpring(p[0], p[1], p[2], p[3]), nil
+		})
+	simple("bounce", "v = 1 - bounce(1-t)", func() NonLinear { return &NLBounce{} })
+
+	Register(CurveInfo{Name: "compound", Curves: -1, Doc: "the curves applied left to right"},
+		func(_ []float64, c []NonLinear) (NonLinear, error) {
+			return NewNLCompound(c), nil
+		})
+	Register(CurveInfo{Name: "omt", Curves: 1, Doc: "v = 1-f(1-t)"},
+		func(_ []float64, c []NonLinear) (NonLinear, error) {
+			return NewNLOmt(c[0]), nil
+		})
+	Register(CurveInfo{Name: "conditional", Params: []string{"ts"}, Curves: 2, Doc: "f below ts, g above"},
+		func(p []float64, c []NonLinear) (NonLinear, error) {
+			if !(p[0] >= 0 && p[0] <= 1) {
+				return nil, invalidParam("ts", p[0], "in [0,1]")
+			}
+			return NewNLConditional(c[0], c[1], p[0]), nil
+		})
+	Register(CurveInfo{Name: "normalized", Curves: 1, Doc: "v = (f(t) - f(0)) / (f(1) - f(0))"},
+		func(_ []float64, c []NonLinear) (NonLinear, error) {
+			return NewNLNormalizedChecked(c[0])
+		})
+	Register(CurveInfo{Name: "exact", Curves: 1, Doc: "f with v exactly 0 at t=0 and 1 at t=1"},
+		func(_ []float64, c []NonLinear) (NonLinear, error) {
+			return NewNLExact(c[0]), nil
+		})
+	Register(CurveInfo{Name: "domain", Params: []string{"mode"}, Curves: 1, Doc: "f with t outside [0,1] handled by mode, 0 = clamp, 1 = extend, 2 = wrap, 3 = mirror"},
+		func(p []float64, c []NonLinear) (NonLinear, error) {
+			mode := Extrapolation(p[0])
+			if !(p[0] >= 0 && p[0] <= 3) || float64(mode) != p[0] {
+				return nil, invalidParam("mode", p[0], "0, 1, 2 or 3")
+			}
+			return NewNLDomain(c[0], mode), nil
+		})
+	Register(CurveInfo{Name: "inout", Params: []string{"split"}, Curves: 2, Doc: "in on [0,split], out on [split,1]"},
+		func(p []float64, c []NonLinear) (NonLinear, error) {
+			if !(p[0] > 0 && p[0] <= 1) {
+				return nil, invalidParam("split", p[0], "in (0,1]")
+			}
+			return NewNLInOut(c[0], c[1], p[0]), nil
+		})
+	Register(CurveInfo{Name: "descend", Curves: 1, Doc: "v = 1 - f(t)"},
+		func(_ []float64, c []NonLinear) (NonLinear, error) {
+			return NewNLDescend(c[0]), nil
+		})
+	Register(CurveInfo{Name: "window", Params: []string{"a", "b"}, Curves: 1, Doc: "f run over [a,b]"},
+		func(p []float64, c []NonLinear) (NonLinear, error) {
+			if !(p[0] >= 0 && p[0] < 1) {
+				return nil, invalidParam("a", p[0], "in [0,1)")
+			}
+			if !(p[1] > p[0] && p[1] <= 1) {
+				return nil, invalidParam("b", p[1], fmt.Sprintf("in (%g,1]", p[0]))
+			}
+			return NewNLWindow(c[0], p[0], p[1]), nil
+		})
+	Register(CurveInfo{Name: "softclamp", Params: []string{"knee"}, Curves: 1, Doc: "f with soft knees outside of [0,1]"},
+		func(p []float64, c []NonLinear) (NonLinear, error) {
+			if !(p[0] >= 0) || !isFinite(p[0]) {
+				return nil, invalidParam("knee", p[0], "finite and >= 0")
+			}
+			return NewNLSoftClamp(c[0], p[0]), nil
+		})
+	Register(CurveInfo{Name: "constantspeed", Params: []string{"samples"}, Curves: 1, Doc: "f reparameterized by arc length"},
+		func(p []float64, c []NonLinear) (NonLinear, error) {
+			if !isInt(p[0], 1, MaxTableSize) {
+				return nil, invalidParam("samples", p[0], fmt.Sprintf("an integer in [1,%d]", MaxTableSize))
+			}
+			return NewNLConstantSpeed(c[0], int(p[0])), nil
+		})
+	Register(CurveInfo{Name: "table", Params: []string{"n"}, Curves: 1, Doc: "f sampled into lookup tables"},
+		func(p []float64, c []NonLinear) (NonLinear, error) {
+			if !isInt(p[0], 2, MaxTableSize) {
+				return nil, invalidParam("n", p[0], fmt.Sprintf("an integer in [2,%d]", MaxTableSize))
+			}
+			return NewNLTable(c[0], int(p[0])), nil
+		})
+	Register(CurveInfo{Name: "cached", Params: []string{"resolution"}, Curves: 1, Doc: "f memoized on a grid"},
+		func(p []float64, c []NonLinear) (NonLinear, error) {
+			if !isInt(p[0], 1, MaxTableSize) {
+				return nil, invalidParam("resolution", p[0], fmt.Sprintf("an integer in [1,%d]", MaxTableSize))
+			}
+			return NewNLCached(c[0], int(p[0])), nil
+		})
+	Register(CurveInfo{Name: "invtable", Params: []string{"n"}, Curves: 1, Doc: "f with its inverse sampled into a lookup table"},
+		func(p []float64, c []NonLinear) (NonLinear, error) {
+			if !isInt(p[0], 2, MaxTableSize) {
+				return nil, invalidParam("n", p[0], fmt.Sprintf("an integer in [2,%d]", MaxTableSize))
+			}
+			return NewNLInvTable(c[0], int(p[0])), nil
+		})
+	Register(CurveInfo{Name: "inverse", Params: []string{"tolerance", "iterations"}, Curves: 1, Doc: "f with a numerical inverse"},
+		func(p []float64, c []NonLinear) (NonLinear, error) {
+			if !(p[0] >= 0) || !isFinite(p[0]) {
+				return nil, invalidParam("tolerance", p[0], "finite and >= 0")
+			}
+			if !isInt(p[1], 1, math.MaxInt32) {
+				return nil, invalidParam("iterations", p[1], fmt.Sprintf("an integer in [1,%d]", math.MaxInt32))
+			}
+			return NewNLInverse(c[0], p[0], int(p[1])), nil
+		})
+}
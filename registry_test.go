@@ -0,0 +1,95 @@
+package nonlinear
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewWithInvalid(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name   string
+		params []float64
+		curves int
+	}{
+		{"conditional", []float64{nan}, 2},
+		{"conditional", []float64{-0.5}, 2},
+		{"conditional", []float64{1.5}, 2},
+		{"inout", []float64{0}, 2},
+		{"inout", []float64{nan}, 2},
+		{"softclamp", []float64{-0.1}, 1},
+		{"softclamp", []float64{nan}, 1},
+		{"constantspeed", []float64{0}, 1},
+		{"constantspeed", []float64{2.5}, 1},
+		{"constantspeed", []float64{nan}, 1},
+		{"inverse", []float64{nan, 32}, 1},
+		{"inverse", []float64{-1e-6, 32}, 1},
+		{"inverse", []float64{1e-6, 0}, 1},
+		{"inverse", []float64{1e-6, 2.5}, 1},
+		{"inverse", []float64{1e-6, nan}, 1},
+		{"inverse", []float64{1e-6, 1e19}, 1},
+		{"steps", []float64{1e19, 0}, 0},
+		{"table", []float64{1e19}, 1},
+		{"cached", []float64{1e19}, 1},
+		{"invtable", []float64{1e19}, 1},
+		{"constantspeed", []float64{1e19}, 1},
+		{"table", []float64{MaxTableSize + 1}, 1},
+	}
+	for _, tt := range tests {
+		curves := make([]NonLinear, tt.curves)
+		for i := range curves {
+			curves[i] = &NLP3{}
+		}
+		if _, err := NewWith(tt.name, tt.params, curves...); err == nil {
+			t.Errorf("NewWith(%q, %v) succeeded, want an error", tt.name, tt.params)
+		}
+	}
+}
+
+func TestNewNLNormalizedChecked(t *testing.T) {
+	flat := NewNLPolynomial(0.5, 1, -1) // f(0) == f(1)
+	if _, err := NewNLNormalizedChecked(flat); err == nil {
+		t.Error("NewNLNormalizedChecked of a curve with f(0) == f(1) succeeded, want an error")
+	}
+	if _, err := NewWith("normalized", nil, flat); err == nil {
+		t.Error("NewWith(normalized) of a curve with f(0) == f(1) succeeded, want an error")
+	}
+	f, err := NewNLNormalizedChecked(NewNLPolynomial(1, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := f.Transform(0.5); v != 0.5 {
+		t.Errorf("f(0.5) = %g, want 0.5", v)
+	}
+}
+
+func TestCheckedScale(t *testing.T) {
+	for _, k := range []float64{800, 1e-320} {
+		if _, err := NewNLExponentialChecked(k); err == nil {
+			t.Errorf("NewNLExponentialChecked(%g) succeeded, want an error", k)
+		}
+	}
+	for _, k := range []float64{1e-300, 1e-8, -700, 700} {
+		f, err := NewNLExponentialChecked(k)
+		if err != nil {
+			t.Errorf("NewNLExponentialChecked(%g): %v", k, err)
+			continue
+		}
+		for _, x := range []float64{0, 0.5, 1} {
+			if v := f.Transform(x); !isFinite(v) {
+				t.Errorf("exponential(%g) at %g = %g", k, x, v)
+			}
+		}
+	}
+	for _, k := range []float64{1e-8, 1e-300} {
+		if _, err := NewNLGaussChecked(k); err == nil {
+			t.Errorf("NewNLGaussChecked(%g) succeeded, want an error", k)
+		}
+		if _, err := NewWith("gauss_fast", []float64{k}); err == nil {
+			t.Errorf("gauss_fast(%g) succeeded, want an error", k)
+		}
+	}
+	if _, err := NewWith("exponential_fast", []float64{1e-300}); err == nil {
+		t.Error("exponential_fast(1e-300) succeeded, want an error")
+	}
+}
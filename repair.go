@@ -0,0 +1,95 @@
+package nonlinear
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// StopRepair reports the changes made by RepairStops.
+type StopRepair struct {
+	Dropped       int     // Stops with a non-finite t or v
+	Merged        int     // Stops merged into another with the same t
+	Adjusted      int     // Stops whose v was changed to make v non-decreasing
+	MaxAdjustment float64 // Largest change made to a v
+}
+
+// RepairStops returns a copy of stops sorted by t, with non-finite stops dropped, stops sharing a t
+// merged into one with their mean v, and v made non-decreasing by isotonic regression, which pools
+// runs of decreasing values into their mean and so moves the values as little as possible, in the
+// least squares sense. Merged stops count toward the mean of a pool by the number merged. Neither t
+// nor v is clamped to [0,1], see NewNLStoppedChecked.
+func RepairStops(stops []Stop) ([]Stop, StopRepair) {
+	var rep StopRepair
+	res := make([]Stop, 0, len(stops))
+	for _, s := range stops {
+		if !isFinite(s.T) || !isFinite(s.V) {
+			rep.Dropped++
+			continue
+		}
+		res = append(res, s)
+	}
+	slices.SortStableFunc(res, func(a, b Stop) int { return cmp.Compare(a.T, b.T) })
+
+	// Merge stops with the same t, remembering how many each one stands for
+	ws := make([]float64, 0, len(res))
+	n := 0
+	for _, s := range res {
+		if n > 0 && res[n-1].T == s.T {
+			w := ws[n-1]
+			res[n-1].V = (res[n-1].V*w + s.V) / (w + 1)
+			ws[n-1]++
+			rep.Merged++
+			continue
+		}
+		res[n] = s
+		ws = append(ws, 1)
+		n++
+	}
+	res = res[:n]
+	orig := make([]float64, n)
+	for i, s := range res {
+		orig[i] = s.V
+	}
+
+	// Pool adjacent violators - each block is a run of stops sharing the weighted mean v
+	type block struct {
+		v, w  float64
+		start int
+	}
+	blocks := make([]block, 0, n)
+	for i, s := range res {
+		b := block{s.V, ws[i], i}
+		for len(blocks) > 0 && blocks[len(blocks)-1].v > b.v {
+			p := blocks[len(blocks)-1]
+			blocks = blocks[:len(blocks)-1]
+			b = block{(p.v*p.w + b.v*b.w) / (p.w + b.w), p.w + b.w, p.start}
+		}
+		blocks = append(blocks, b)
+	}
+	for i, b := range blocks {
+		end := n
+		if i+1 < len(blocks) {
+			end = blocks[i+1].start
+		}
+		for j := b.start; j < end; j++ {
+			res[j].V = b.v
+		}
+	}
+
+	for i, s := range res {
+		if d := math.Abs(s.V - orig[i]); d > 0 {
+			rep.Adjusted++
+			rep.MaxAdjustment = math.Max(rep.MaxAdjustment, d)
+		}
+	}
+	return res, rep
+}
+
+// SimplifyStops returns the fewest of stops, which must be ordered by t, needed for the curve through
+// them to stay within epsilon in v of the original, using the Ramer-Douglas-Peucker algorithm with the
+// distance measured vertically. The first and last stops are always kept, so any implicit end stops
+// are unaffected, as are jumps larger than epsilon.
+func SimplifyStops(stops []Stop, epsilon float64) []Stop {
+	return simplifyStops(stops, 0, epsilon)
+}
@@ -0,0 +1,71 @@
+package nonlinear
+
+import (
+	"encoding/csv"
+	"io"
+	"iter"
+	"strconv"
+)
+
+// SampleFormat selects the layout used by WriteSamples.
+type SampleFormat int
+
+const (
+	CSV            SampleFormat = iota   // Comma separated
+	TSV                                  // Tab separated
+	WithDerivative SampleFormat = 1 << 8 // Or'd with CSV or TSV to add a dv/dt column
+)
+
+// Samples returns an iterator over the n+1 pairs (t, f(t)) for t evenly spaced in [0,1].
+// n < 1 is treated as 1.
+func Samples(f NonLinear, n int) iter.Seq2[float64, float64] {
+	if n < 1 {
+		n = 1
+	}
+	return func(yield func(float64, float64) bool) {
+		for i := 0; i <= n; i++ {
+			t := float64(i) / float64(n)
+			if !yield(t, f.Transform(t)) {
+				return
+			}
+		}
+	}
+}
+
+// AdaptiveSamples returns a polyline of (t, f(t)) points, from t = 0 to t = 1, that's within about
+// maxErr of f. Points are placed densely where f bends sharply and sparsely where it's flat.
+func AdaptiveSamples(f NonLinear, maxErr float64) [][]float64 {
+	return adaptivePoints(f, maxErr)
+}
+
+// WriteSamples writes a header row followed by n+1 rows of t, v (and dv/dt if requested) for
+// t evenly spaced in [0,1]. n < 1 is treated as 1.
+func WriteSamples(w io.Writer, f NonLinear, n int, format SampleFormat) error {
+	if n < 1 {
+		n = 1
+	}
+	cw := csv.NewWriter(w)
+	if format&^WithDerivative == TSV {
+		cw.Comma = '\t'
+	}
+	deriv := format&WithDerivative != 0
+	row := []string{"t", "v"}
+	if deriv {
+		row = append(row, "dv")
+	}
+	if err := cw.Write(row); err != nil {
+		return err
+	}
+	for t, v := range Samples(f, n) {
+		row[0] = strconv.FormatFloat(t, 'g', -1, 64)
+		row[1] = strconv.FormatFloat(v, 'g', -1, 64)
+		if deriv {
+			row[2] = strconv.FormatFloat(Derivative(f, t), 'g', -1, 64)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,41 @@
+package nonlinear
+
+// Batch kernels for the common curves, used by TransformBatch. Each sets dst[i] for i < len(ts), and
+// dst must be at least as long as ts. The Go versions here are the fallback for the assembly ones in
+// simd_amd64.s, and handle the elements left over from them. Both compute the same expressions in the
+// same order as the scalar code so that batch and single results agree.
+
+func squareGo(ts, dst []float64) {
+	dst = dst[:len(ts)]
+	for i, t := range ts {
+		dst[i] = t * t
+	}
+}
+
+func cubeGo(ts, dst []float64) {
+	dst = dst[:len(ts)]
+	for i, t := range ts {
+		dst[i] = t * t * t
+	}
+}
+
+func p3Go(ts, dst []float64) {
+	dst = dst[:len(ts)]
+	for i, t := range ts {
+		dst[i] = t * t * (3 - 2*t)
+	}
+}
+
+func p5Go(ts, dst []float64) {
+	dst = dst[:len(ts)]
+	for i, t := range ts {
+		dst[i] = t * t * t * (t*(t*6.0-15.0) + 10.0)
+	}
+}
+
+func fastExpGo(xs, dst []float64) {
+	dst = dst[:len(xs)]
+	for i, x := range xs {
+		dst[i] = fastExp(x)
+	}
+}
@@ -0,0 +1,63 @@
+//go:build amd64 && !purego
+
+package nonlinear
+
+// useAVX2 is set if the CPU and OS support AVX2, in which case the batch kernels process four
+// values at a time in assembly.
+var useAVX2 = hasAVX2()
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+func xgetbv() (eax, edx uint32)
+
+func hasAVX2() bool {
+	if max, _, _, _ := cpuid(0, 0); max < 7 {
+		return false
+	}
+	_, _, ecx1, _ := cpuid(1, 0)
+	const osxsave, avx = 1 << 27, 1 << 28
+	if ecx1&osxsave == 0 || ecx1&avx == 0 {
+		return false
+	}
+	// The OS must save the XMM and YMM registers
+	if xcr0, _ := xgetbv(); xcr0&6 != 6 {
+		return false
+	}
+	_, ebx7, _, _ := cpuid(7, 0)
+	return ebx7&(1<<5) != 0
+}
+
+// The assembly kernels process len(ts) rounded down to a multiple of 4 values.
+
+//go:noescape
+func squareAVX2(ts, dst []float64)
+
+//go:noescape
+func cubeAVX2(ts, dst []float64)
+
+//go:noescape
+func p3AVX2(ts, dst []float64)
+
+//go:noescape
+func p5AVX2(ts, dst []float64)
+
+//go:noescape
+func fastExpAVX2(xs, dst []float64)
+
+// kernel runs asm over as much of ts as it can and gen over the rest.
+func kernel(asm, gen func(ts, dst []float64), ts, dst []float64) {
+	dst = dst[:len(ts)]
+	if useAVX2 {
+		n := len(ts) &^ 3
+		if n > 0 {
+			asm(ts[:n], dst[:n])
+		}
+		ts, dst = ts[n:], dst[n:]
+	}
+	gen(ts, dst)
+}
+
+func squareSlice(ts, dst []float64)  { kernel(squareAVX2, squareGo, ts, dst) }
+func cubeSlice(ts, dst []float64)    { kernel(cubeAVX2, cubeGo, ts, dst) }
+func p3Slice(ts, dst []float64)      { kernel(p3AVX2, p3Go, ts, dst) }
+func p5Slice(ts, dst []float64)      { kernel(p5AVX2, p5Go, ts, dst) }
+func fastExpSlice(xs, dst []float64) { kernel(fastExpAVX2, fastExpGo, xs, dst) }
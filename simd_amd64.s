@@ -0,0 +1,197 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// Each kernel loads SI = ts, DI = dst and CX = len(ts)/4, and loops over four values at a time.
+#define PROLOGUE \
+	MOVQ ts_base+0(FP), SI \
+	MOVQ ts_len+8(FP), CX \
+	MOVQ dst_base+24(FP), DI \
+	SHRQ $2, CX
+
+#define NEXT(loop) \
+	ADDQ $32, SI \
+	ADDQ $32, DI \
+	DECQ CX \
+	JNZ  loop
+
+// Constants for fastExpAVX2, broadcast into all four lanes when loaded.
+DATA expConsts<>+0x00(SB)/8, $0x3ff71547652b82fe // 1/Ln2
+DATA expConsts<>+0x08(SB)/8, $0x3fe0000000000000 // 0.5
+DATA expConsts<>+0x10(SB)/8, $0x3fe62e42fefa39ef // Ln2
+DATA expConsts<>+0x18(SB)/8, $0x3ff0000000000000 // 1
+DATA expConsts<>+0x20(SB)/8, $0x3fc5555555555555 // 1/6
+DATA expConsts<>+0x28(SB)/8, $0x3fa5555555555555 // 1/24
+DATA expConsts<>+0x30(SB)/8, $0x43300000000003ff // 2^52 + 1023
+DATA expConsts<>+0x38(SB)/8, $0xc086200000000000 // -708
+DATA expConsts<>+0x40(SB)/8, $0x4086280000000000 // 709
+DATA expConsts<>+0x48(SB)/8, $0x7ff0000000000000 // +Inf
+GLOBL expConsts<>(SB), RODATA|NOPTR, $0x50
+
+// Constants for the polynomial kernels.
+DATA polyConsts<>+0x00(SB)/8, $0x4000000000000000 // 2
+DATA polyConsts<>+0x08(SB)/8, $0x4008000000000000 // 3
+DATA polyConsts<>+0x10(SB)/8, $0x4018000000000000 // 6
+DATA polyConsts<>+0x18(SB)/8, $0x402e000000000000 // 15
+DATA polyConsts<>+0x20(SB)/8, $0x4024000000000000 // 10
+GLOBL polyConsts<>(SB), RODATA|NOPTR, $0x28
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func xgetbv() (eax, edx uint32)
+TEXT ·xgetbv(SB), NOSPLIT, $0-8
+	MOVL $0, CX
+	XGETBV
+	MOVL AX, eax+0(FP)
+	MOVL DX, edx+4(FP)
+	RET
+
+// func squareAVX2(ts, dst []float64)
+TEXT ·squareAVX2(SB), NOSPLIT, $0-48
+	PROLOGUE
+	JZ done
+
+loop:
+	VMOVUPD (SI), Y0
+	VMULPD  Y0, Y0, Y1
+	VMOVUPD Y1, (DI)
+	NEXT(loop)
+
+done:
+	VZEROUPPER
+	RET
+
+// func cubeAVX2(ts, dst []float64)
+TEXT ·cubeAVX2(SB), NOSPLIT, $0-48
+	PROLOGUE
+	JZ done
+
+loop:
+	VMOVUPD (SI), Y0
+	VMULPD  Y0, Y0, Y1
+	VMULPD  Y0, Y1, Y1
+	VMOVUPD Y1, (DI)
+	NEXT(loop)
+
+done:
+	VZEROUPPER
+	RET
+
+// func p3AVX2(ts, dst []float64)
+// t * t * (3 - 2*t)
+TEXT ·p3AVX2(SB), NOSPLIT, $0-48
+	PROLOGUE
+	JZ           done
+	VBROADCASTSD polyConsts<>+0x00(SB), Y8
+	VBROADCASTSD polyConsts<>+0x08(SB), Y9
+
+loop:
+	VMOVUPD (SI), Y0
+	VMULPD  Y0, Y0, Y1
+	VMULPD  Y8, Y0, Y2
+	VSUBPD  Y2, Y9, Y2
+	VMULPD  Y2, Y1, Y1
+	VMOVUPD Y1, (DI)
+	NEXT(loop)
+
+done:
+	VZEROUPPER
+	RET
+
+// func p5AVX2(ts, dst []float64)
+// t * t * t * (t*(t*6 - 15) + 10)
+TEXT ·p5AVX2(SB), NOSPLIT, $0-48
+	PROLOGUE
+	JZ           done
+	VBROADCASTSD polyConsts<>+0x10(SB), Y8
+	VBROADCASTSD polyConsts<>+0x18(SB), Y9
+	VBROADCASTSD polyConsts<>+0x20(SB), Y10
+
+loop:
+	VMOVUPD (SI), Y0
+	VMULPD  Y0, Y0, Y1
+	VMULPD  Y0, Y1, Y1
+	VMULPD  Y8, Y0, Y2
+	VSUBPD  Y9, Y2, Y2
+	VMULPD  Y0, Y2, Y2
+	VADDPD  Y10, Y2, Y2
+	VMULPD  Y2, Y1, Y1
+	VMOVUPD Y1, (DI)
+	NEXT(loop)
+
+done:
+	VZEROUPPER
+	RET
+
+// func fastExpAVX2(xs, dst []float64)
+// As fastExp: n = floor(x/Ln2 + 0.5), r = x - n*Ln2, exp(x) = p(r) * 2^n with 2^n formed by
+// shifting n + 1023 into the exponent bits, recovered from the low bits of n + 2^52 + 1023.
+TEXT ·fastExpAVX2(SB), NOSPLIT, $0-48
+	MOVQ         xs_base+0(FP), SI
+	MOVQ         xs_len+8(FP), CX
+	MOVQ         dst_base+24(FP), DI
+	SHRQ         $2, CX
+	JZ           done
+	VXORPD       Y6, Y6, Y6
+	VBROADCASTSD expConsts<>+0x00(SB), Y7
+	VBROADCASTSD expConsts<>+0x08(SB), Y8
+	VBROADCASTSD expConsts<>+0x10(SB), Y9
+	VBROADCASTSD expConsts<>+0x18(SB), Y10
+	VBROADCASTSD expConsts<>+0x20(SB), Y11
+	VBROADCASTSD expConsts<>+0x28(SB), Y12
+	VBROADCASTSD expConsts<>+0x30(SB), Y13
+	VBROADCASTSD expConsts<>+0x38(SB), Y14
+
+loop:
+	VMOVUPD (SI), Y0
+
+	// n
+	VMULPD   Y7, Y0, Y1
+	VADDPD   Y8, Y1, Y1
+	VROUNDPD $1, Y1, Y1
+
+	// r
+	VMULPD Y9, Y1, Y2
+	VSUBPD Y2, Y0, Y2
+
+	// p(r)
+	VMULPD Y12, Y2, Y3
+	VADDPD Y11, Y3, Y3
+	VMULPD Y2, Y3, Y3
+	VADDPD Y8, Y3, Y3
+	VMULPD Y2, Y3, Y3
+	VADDPD Y10, Y3, Y3
+	VMULPD Y2, Y3, Y3
+	VADDPD Y10, Y3, Y3
+
+	// 2^n
+	VADDPD Y13, Y1, Y4
+	VPSLLQ $52, Y4, Y4
+	VMULPD Y4, Y3, Y3
+
+	// 0 below -708, +Inf above 709
+	VCMPPD       $1, Y14, Y0, Y5
+	VBLENDVPD    Y5, Y6, Y3, Y3
+	VBROADCASTSD expConsts<>+0x40(SB), Y1
+	VCMPPD       $0x1e, Y1, Y0, Y5
+	VBROADCASTSD expConsts<>+0x48(SB), Y1
+	VBLENDVPD    Y5, Y1, Y3, Y3
+
+	VMOVUPD Y3, (DI)
+	ADDQ    $32, SI
+	ADDQ    $32, DI
+	DECQ    CX
+	JNZ     loop
+
+done:
+	VZEROUPPER
+	RET
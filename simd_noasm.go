@@ -0,0 +1,9 @@
+//go:build !amd64 || purego
+
+package nonlinear
+
+func squareSlice(ts, dst []float64)  { squareGo(ts, dst) }
+func cubeSlice(ts, dst []float64)    { cubeGo(ts, dst) }
+func p3Slice(ts, dst []float64)      { p3Go(ts, dst) }
+func p5Slice(ts, dst []float64)      { p5Go(ts, dst) }
+func fastExpSlice(xs, dst []float64) { fastExpGo(xs, dst) }
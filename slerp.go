@@ -0,0 +1,62 @@
+package nonlinear
+
+import "math"
+
+// Slerp performs spherical interpolation between the unit vectors a and b, of any dimension, with the
+// fraction of the angle between them given by f at t (clamped to [0,1]). If a and b are opposite, an
+// arbitrary perpendicular great circle is used.
+func Slerp(t float64, a, b []float64, f NonLinear) []float64 {
+	n := len(a)
+	res := make([]float64, n)
+	s := NLerp(t, 0, 1, f)
+	dot := 0.0
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+	dot = math.Max(-1, math.Min(1, dot))
+	theta := math.Acos(dot)
+	// p is the unit vector perpendicular to a in the plane of a and b
+	p := make([]float64, n)
+	pl := 0.0
+	for i := range p {
+		p[i] = b[i] - dot*a[i]
+		pl += p[i] * p[i]
+	}
+	if pl < 1e-24 {
+		if dot > 0 || n < 2 {
+			copy(res, a)
+			return res
+		}
+		perpendicular(a, p)
+		pl = 1
+	}
+	pl = math.Sqrt(pl)
+	c, sn := math.Cos(s*theta), math.Sin(s*theta)
+	for i := range res {
+		res[i] = c*a[i] + sn*p[i]/pl
+	}
+	return res
+}
+
+// perpendicular sets p to a unit vector perpendicular to the unit vector a.
+func perpendicular(a, p []float64) {
+	// Start from the axis least aligned with a
+	k := 0
+	for i := range a {
+		if math.Abs(a[i]) < math.Abs(a[k]) {
+			k = i
+		}
+	}
+	l := 0.0
+	for i := range p {
+		p[i] = -a[k] * a[i]
+		if i == k {
+			p[i]++
+		}
+		l += p[i] * p[i]
+	}
+	l = math.Sqrt(l)
+	for i := range p {
+		p[i] /= l
+	}
+}
@@ -0,0 +1,24 @@
+package nonlinear
+
+import "math"
+
+// Smooth moves current towards target by exponential decay, such that the remaining distance halves
+// every halfLife. Since the decay over dt is 2^(-dt/halfLife), the result is the same whether a period
+// is covered in one step or many, so it behaves identically at any frame rate. halfLife <= 0 returns
+// target.
+func Smooth(current, target, dt, halfLife float64) float64 {
+	if halfLife <= 0 {
+		return target
+	}
+	return target + (current-target)*math.Exp2(-dt/halfLife)
+}
+
+// SmoothNL is Smooth performed in the t space of f over the range [start, end], i.e. the values are
+// mapped with InvNLerp, smoothed, and mapped back with NLerp. With a logarithmic f, for example, zoom
+// levels or volumes approach their target at a perceptually even rate. Like Smooth it's frame rate
+// independent. Values are clamped to the range.
+func SmoothNL(current, target, start, end, dt, halfLife float64, f NonLinear) float64 {
+	c := InvNLerp(current, start, end, f)
+	t := InvNLerp(target, start, end, f)
+	return NLerp(Smooth(c, t, dt, halfLife), start, end, f)
+}
@@ -0,0 +1,84 @@
+package nonlinear
+
+import "math"
+
+// SmoothDamp moves a value towards a target, which may change at any time, using a critically damped
+// spring so that neither the value nor its velocity jump when the target moves. It's the same model as
+// Unity's SmoothDamp.
+//
+// If Response is set, the approach is eased: the damped progress from the value at the time the target
+// was last set towards the target is passed through Response before being applied. The value is still
+// continuous, but the velocity is only continuous across target changes if Response has a slope of 1
+// at t=0.
+type SmoothDamp struct {
+	SmoothTime float64   // Approximate time taken to reach the target
+	MaxSpeed   float64   // Maximum speed of the undamped approach, 0 for no limit
+	Response   NonLinear // Optional easing of the approach
+
+	value, target, from float64
+	raw, velocity       float64
+}
+
+// NewSmoothDamp returns a SmoothDamp at rest at value.
+func NewSmoothDamp(value, smoothTime float64) *SmoothDamp {
+	return &SmoothDamp{SmoothTime: smoothTime, value: value, target: value, from: value, raw: value}
+}
+
+// Value returns the current value.
+func (s *SmoothDamp) Value() float64 {
+	return s.value
+}
+
+// Velocity returns the current velocity of the damped value, before any Response is applied.
+func (s *SmoothDamp) Velocity() float64 {
+	return s.velocity
+}
+
+// Target returns the current target.
+func (s *SmoothDamp) Target() float64 {
+	return s.target
+}
+
+// SetTarget changes the target, keeping the current value and velocity.
+func (s *SmoothDamp) SetTarget(target float64) {
+	if target == s.target {
+		return
+	}
+	s.target = target
+	s.from, s.raw = s.value, s.value
+}
+
+// Reset places the value at rest at value, with the target also set to value.
+func (s *SmoothDamp) Reset(value float64) {
+	s.value, s.target, s.from, s.raw, s.velocity = value, value, value, value, 0
+}
+
+// Update advances the value by dt and returns it. The result is independent of how a given period is
+// split into steps, other than when MaxSpeed is limiting.
+func (s *SmoothDamp) Update(dt float64) float64 {
+	if dt <= 0 {
+		return s.value
+	}
+	st := math.Max(1e-4, s.SmoothTime)
+	omega := 2 / st
+	decay := math.Exp(-omega * dt)
+	change := s.raw - s.target
+	if s.MaxSpeed > 0 {
+		mc := s.MaxSpeed * st
+		change = math.Max(-mc, math.Min(mc, change))
+	}
+	temp := (s.velocity + omega*change) * dt
+	s.velocity = (s.velocity - omega*temp) * decay
+	raw := s.raw - change + (change+temp)*decay
+	// Don't overshoot
+	if (s.target-s.raw > 0) == (raw > s.target) {
+		raw, s.velocity = s.target, 0
+	}
+	s.raw = raw
+	s.value = raw
+	if s.Response != nil && s.target != s.from {
+		p := (raw - s.from) / (s.target - s.from)
+		s.value = NLerp(p, s.from, s.target, s.Response)
+	}
+	return s.value
+}
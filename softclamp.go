@@ -0,0 +1,83 @@
+package nonlinear
+
+import "math"
+
+// NLSoftClamp extends f outside of [0,1] with an exponential knee so that values of t slightly
+// out of range ease towards -knee and 1+knee rather than being clamped hard to 0 and 1.
+// The slope of f is matched at both endpoints. Use with NLerpUnclamped.
+type NLSoftClamp struct {
+	F      NonLinear
+	Knee   float64
+	D0, D1 float64 // Slopes of f at t=0 and t=1
+}
+
+func NewNLSoftClamp(f NonLinear, knee float64) *NLSoftClamp {
+	h := 1e-6
+	d0 := (f.Transform(h) - f.Transform(0)) / h
+	d1 := (f.Transform(1) - f.Transform(1-h)) / h
+	return &NLSoftClamp{f, knee, d0, d1}
+}
+
+func (nl *NLSoftClamp) Transform(t float64) float64 {
+	if t < 0 {
+		if nl.Knee <= 0 || nl.D0 <= 0 {
+			return 0
+		}
+		return nl.Knee * math.Expm1(t*nl.D0/nl.Knee)
+	}
+	if t > 1 {
+		if nl.Knee <= 0 || nl.D1 <= 0 {
+			return 1
+		}
+		return 1 - nl.Knee*math.Expm1((1-t)*nl.D1/nl.Knee)
+	}
+	return nl.F.Transform(t)
+}
+
+func (nl *NLSoftClamp) InvTransform(v float64) float64 {
+	if v < 0 {
+		if nl.Knee <= 0 || nl.D0 <= 0 {
+			return 0
+		}
+		return math.Log1p(v/nl.Knee) * nl.Knee / nl.D0
+	}
+	if v > 1 {
+		if nl.Knee <= 0 || nl.D1 <= 0 {
+			return 1
+		}
+		return 1 - math.Log1p(-(v-1)/nl.Knee)*nl.Knee/nl.D1
+	}
+	return nl.F.InvTransform(v)
+}
+
+func (nl *NLSoftClamp) Derivative(t float64) float64 {
+	if t < 0 {
+		if nl.Knee <= 0 || nl.D0 <= 0 {
+			return 0
+		}
+		return nl.D0 * math.Exp(t*nl.D0/nl.Knee)
+	}
+	if t > 1 {
+		if nl.Knee <= 0 || nl.D1 <= 0 {
+			return 0
+		}
+		return nl.D1 * math.Exp((1-t)*nl.D1/nl.Knee)
+	}
+	return Derivative(nl.F, t)
+}
+
+func (nl *NLSoftClamp) SecondDerivative(t float64) float64 {
+	if t < 0 {
+		if nl.Knee <= 0 || nl.D0 <= 0 {
+			return 0
+		}
+		return nl.D0 * nl.D0 / nl.Knee * math.Exp(t*nl.D0/nl.Knee)
+	}
+	if t > 1 {
+		if nl.Knee <= 0 || nl.D1 <= 0 {
+			return 0
+		}
+		return -nl.D1 * nl.D1 / nl.Knee * math.Exp((1-t)*nl.D1/nl.Knee)
+	}
+	return SecondDerivative(nl.F, t)
+}
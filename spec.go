@@ -0,0 +1,186 @@
+package nonlinear
+
+// curveSpec is the registry description of a curve - its registered name, the parameters, and the
+// curves it wraps - from which it can be rebuilt with NewWith.
+type curveSpec struct {
+	Name   string
+	Params []float64
+	Curves []NonLinear
+}
+
+// specifier is implemented by curves that can be described by a curveSpec.
+type specifier interface {
+	spec() curveSpec
+}
+
+func (nl *NLLinear) spec() curveSpec {
+	return curveSpec{Name: "linear"}
+}
+
+func (nl *NLSquare) spec() curveSpec {
+	return curveSpec{Name: "square"}
+}
+
+func (nl *NLCube) spec() curveSpec {
+	return curveSpec{Name: "cube"}
+}
+
+func (nl *NLExponential) spec() curveSpec {
+	return curveSpec{fastName("exponential", nl.Fast), []float64{nl.K}, nil}
+}
+
+func (nl *NLLogarithmic) spec() curveSpec {
+	return curveSpec{"logarithmic", []float64{nl.K}, nil}
+}
+
+func (nl *NLSin) spec() curveSpec {
+	return curveSpec{Name: fastName("sin", nl.Fast)}
+}
+
+func (nl *NLSin1) spec() curveSpec {
+	return curveSpec{Name: "sin1"}
+}
+
+func (nl *NLSin2) spec() curveSpec {
+	return curveSpec{Name: "sin2"}
+}
+
+func (nl *NLCircle1) spec() curveSpec {
+	return curveSpec{Name: "circle1"}
+}
+
+func (nl *NLCircle2) spec() curveSpec {
+	return curveSpec{Name: "circle2"}
+}
+
+func (nl *NLLame) spec() curveSpec {
+	return curveSpec{"lame", []float64{nl.N, nl.M}, nil}
+}
+
+func (nl *NLCatenary) spec() curveSpec {
+	return curveSpec{Name: "catenary"}
+}
+
+func (nl *NLGauss) spec() curveSpec {
+	return curveSpec{fastName("gauss", nl.Fast), []float64{nl.K}, nil}
+}
+
+func (nl *NLLogistic) spec() curveSpec {
+	return curveSpec{fastName("logistic", nl.Fast), []float64{nl.K, nl.Mp}, nil}
+}
+
+func (nl *NLP3) spec() curveSpec {
+	return curveSpec{Name: "p3"}
+}
+
+func (nl *NLP5) spec() curveSpec {
+	return curveSpec{Name: "p5"}
+}
+
+func (nl *NLCompound) spec() curveSpec {
+	return curveSpec{"compound", nil, nl.Fs}
+}
+
+func (nl *NLOmt) spec() curveSpec {
+	return curveSpec{"omt", nil, []NonLinear{nl.F}}
+}
+
+func (nl *NLStopped) spec() curveSpec {
+	p := make([]float64, 0, 2*len(nl.Stops))
+	for _, s := range nl.Stops {
+		p = append(p, s[0], s[1])
+	}
+	return curveSpec{"stops", p, nil}
+}
+
+func (nl *NLSpline) spec() curveSpec {
+	p := make([]float64, 0, 2*len(nl.Knots))
+	for _, k := range nl.Knots {
+		p = append(p, k[0], k[1])
+	}
+	return curveSpec{"spline", p, nil}
+}
+
+func (nl *NLPolynomial) spec() curveSpec {
+	return curveSpec{"polynomial", append([]float64(nil), nl.C...), nil}
+}
+
+func (nl *NLSpring) spec() curveSpec {
+	return curveSpec{"spring", []float64{nl.Stiffness, nl.Damping, nl.Mass, nl.Duration}, nil}
+}
+
+func (nl *NLConditional) spec() curveSpec {
+	return curveSpec{"conditional", []float64{nl.Ts}, []NonLinear{nl.F, nl.G}}
+}
+
+func (nl *NLNormalized) spec() curveSpec {
+	return curveSpec{"normalized", nil, []NonLinear{nl.F}}
+}
+
+func (nl *NLExact) spec() curveSpec {
+	return curveSpec{"exact", nil, []NonLinear{nl.F}}
+}
+
+func (nl *NLDomain) spec() curveSpec {
+	return curveSpec{"domain", []float64{float64(nl.Mode)}, []NonLinear{nl.F}}
+}
+
+func (nl *NLInOut) spec() curveSpec {
+	return curveSpec{"inout", []float64{nl.Split}, []NonLinear{nl.In, nl.Out}}
+}
+
+func (nl *NLDescend) spec() curveSpec {
+	return curveSpec{"descend", nil, []NonLinear{nl.F}}
+}
+
+func (nl *NLSoftClamp) spec() curveSpec {
+	return curveSpec{"softclamp", []float64{nl.Knee}, []NonLinear{nl.F}}
+}
+
+func (nl *NLConstantSpeed) spec() curveSpec {
+	return curveSpec{"constantspeed", []float64{float64(len(nl.T) - 1)}, []NonLinear{nl.F}}
+}
+
+func (nl *NLInverse) spec() curveSpec {
+	return curveSpec{"inverse", []float64{nl.Options.Tolerance, float64(nl.Options.MaxIterations)}, []NonLinear{nl.F}}
+}
+
+func (nl *NLCubicBezier) spec() curveSpec {
+	return curveSpec{"cubicbezier", []float64{nl.X1, nl.Y1, nl.X2, nl.Y2}, nil}
+}
+
+func (nl *NLSteps) spec() curveSpec {
+	return curveSpec{"steps", []float64{float64(nl.N), float64(nl.Position)}, nil}
+}
+
+func (nl *NLPower) spec() curveSpec {
+	return curveSpec{fastName("power", nl.Fast), []float64{nl.P}, nil}
+}
+
+func (nl *NLBack) spec() curveSpec {
+	return curveSpec{"back", []float64{nl.C}, nil}
+}
+
+func (nl *NLElastic) spec() curveSpec {
+	return curveSpec{"elastic", []float64{nl.P}, nil}
+}
+
+func (nl *NLBounce) spec() curveSpec {
+	return curveSpec{Name: "bounce"}
+}
+
+func (nl *NLWindow) spec() curveSpec {
+	return curveSpec{"window", []float64{nl.A, nl.B}, []NonLinear{nl.F}}
+}
+
+func (nl *NLTable) spec() curveSpec {
+	return curveSpec{"table", []float64{float64(len(nl.Vs))}, []NonLinear{nl.F}}
+}
+
+func (nl *NLCached) spec() curveSpec {
+	return curveSpec{"cached", []float64{float64(nl.Resolution)}, []NonLinear{nl.F}}
+}
+
+func (nl *NLInvTable) spec() curveSpec {
+	return curveSpec{"invtable", []float64{float64(len(nl.Ts))}, []NonLinear{nl.F}}
+}
@@ -0,0 +1,85 @@
+package nonlinear
+
+import "sort"
+
+// NLConstantSpeed reparameterizes f by the arc length of its graph so that the point (t, f(t))
+// moves at a uniform speed. v = f(t(s)) where s is the normalized arc length.
+type NLConstantSpeed struct {
+	F NonLinear
+	T []float64 // Sample positions, ascending in [0,1]
+	S []float64 // Normalized cumulative arc length at each sample, ascending in [0,1]
+}
+
+// NewNLConstantSpeed tabulates the arc length of f at samples+1 points and interpolates linearly
+// between them. samples < 1 is treated as 1.
+func NewNLConstantSpeed(f NonLinear, samples int) *NLConstantSpeed {
+	if samples < 1 {
+		samples = 1
+	}
+	ts := make([]float64, samples+1)
+	ss := make([]float64, samples+1)
+	pt := 0.0
+	dt := 1 / float64(samples)
+	for i := 1; i <= samples; i++ {
+		t := float64(i) * dt
+		ts[i] = t
+		ss[i] = ss[i-1] + arcLength(f, pt, t)
+		pt = t
+	}
+	ts[samples] = 1
+	l := ss[samples]
+	for i := range ss {
+		ss[i] /= l
+	}
+	ss[samples] = 1
+	return &NLConstantSpeed{f, ts, ss}
+}
+
+func (nl *NLConstantSpeed) Transform(s float64) float64 {
+	return nl.F.Transform(tableLerp(s, nl.S, nl.T))
+}
+
+func (nl *NLConstantSpeed) InvTransform(v float64) float64 {
+	return tableLerp(nl.F.InvTransform(v), nl.T, nl.S)
+}
+
+func (nl *NLConstantSpeed) Derivative(s float64) float64 {
+	return Derivative(nl.F, tableLerp(s, nl.S, nl.T)) * tableSlope(s, nl.S, nl.T)
+}
+
+func (nl *NLConstantSpeed) SecondDerivative(s float64) float64 {
+	dt := tableSlope(s, nl.S, nl.T)
+	return SecondDerivative(nl.F, tableLerp(s, nl.S, nl.T)) * dt * dt
+}
+
+// tableLerp finds x in the ascending xs and returns the linearly interpolated value from ys.
+func tableLerp(x float64, xs, ys []float64) float64 {
+	n := len(xs)
+	i := sort.SearchFloat64s(xs, x)
+	if i == 0 {
+		return ys[0]
+	}
+	if i == n {
+		return ys[n-1]
+	}
+	x0, x1 := xs[i-1], xs[i]
+	dx := x1 - x0
+	if dx == 0 {
+		return ys[i]
+	}
+	x = (x - x0) / dx
+	return (1-x)*ys[i-1] + x*ys[i]
+}
+
+// tableSlope returns the slope of the segment of the table containing x.
+func tableSlope(x float64, xs, ys []float64) float64 {
+	n := len(xs)
+	i := sort.SearchFloat64s(xs, x)
+	if i == 0 {
+		i = 1
+	}
+	if i == n {
+		i = n - 1
+	}
+	return (ys[i] - ys[i-1]) / (xs[i] - xs[i-1])
+}
@@ -0,0 +1,201 @@
+package nonlinear
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// NLSpline is a monotone cubic Hermite spline through the supplied knots, with t increasing in [0,1].
+// As with NLStopped, implicit knots at (0,0) and (1,1) are added unless the first knot is at t=0, or
+// the last at t=1. The tangents are chosen as by Fritsch and Carlson, so the spline is monotonic
+// wherever the knots are and has no overshoot. Outside of [0,1] the end tangents are extended.
+type NLSpline struct {
+	Knots [][]float64 // Pairs of t, v with t increasing in [0,1]
+
+	// Knot tables built by NewNLSpline, including any implicit knots, and the tangents
+	xs, vs, ms []float64
+}
+
+// NewNLSpline precomputes the tangents, so Knots shouldn't be modified afterwards. The knots aren't
+// checked, see the registry's "spline".
+func NewNLSpline(knots [][]float64) *NLSpline {
+	nl := &NLSpline{Knots: knots}
+	nl.xs, nl.vs, nl.ms = splineTables(knots)
+	return nl
+}
+
+// splineTables returns the knot positions and values, with any implicit end knots added, and the
+// tangent at each knot.
+func splineTables(knots [][]float64) ([]float64, []float64, []float64) {
+	xs, vs, _ := stopTables(knots)
+	n := len(xs)
+	ds := make([]float64, n-1)
+	for i := range ds {
+		if h := xs[i+1] - xs[i]; h > 0 {
+			ds[i] = (vs[i+1] - vs[i]) / h
+		}
+	}
+	ms := make([]float64, n)
+	ms[0], ms[n-1] = ds[0], ds[n-2]
+	for i := 1; i < n-1; i++ {
+		d0, d1 := ds[i-1], ds[i]
+		if d0*d1 <= 0 {
+			continue
+		}
+		// Weighted harmonic mean of the neighboring slopes
+		h0, h1 := xs[i]-xs[i-1], xs[i+1]-xs[i]
+		w0, w1 := 2*h1+h0, h1+2*h0
+		ms[i] = (w0 + w1) / (w0/d0 + w1/d1)
+	}
+	return xs, vs, ms
+}
+
+func (nl *NLSpline) tables() ([]float64, []float64, []float64) {
+	if nl.xs == nil {
+		// Not made by NewNLSpline
+		return splineTables(nl.Knots)
+	}
+	return nl.xs, nl.vs, nl.ms
+}
+
+// segment returns the index of the segment containing t, the Hermite basis arguments and the
+// tables. For t outside of the knots, i is -1 or the last knot.
+func (nl *NLSpline) segment(t float64) (int, []float64, []float64, []float64) {
+	xs, vs, ms := nl.tables()
+	n := len(xs)
+	if t < xs[0] {
+		return -1, xs, vs, ms
+	}
+	if t >= xs[n-1] {
+		return n - 1, xs, vs, ms
+	}
+	lo, hi := 1, n-1
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if xs[m] > t {
+			hi = m
+		} else {
+			lo = m + 1
+		}
+	}
+	return lo - 1, xs, vs, ms
+}
+
+func (nl *NLSpline) Transform(t float64) float64 {
+	i, xs, vs, ms := nl.segment(t)
+	switch {
+	case i < 0:
+		return vs[0] + (t-xs[0])*ms[0]
+	case i == len(xs)-1:
+		return vs[i] + (t-xs[i])*ms[i]
+	}
+	h := xs[i+1] - xs[i]
+	s := (t - xs[i]) / h
+	s2, s3 := s*s, s*s*s
+	return (2*s3-3*s2+1)*vs[i] + (s3-2*s2+s)*h*ms[i] + (-2*s3+3*s2)*vs[i+1] + (s3-s2)*h*ms[i+1]
+}
+
+func (nl *NLSpline) InvTransform(v float64) float64 {
+	return bsInv(v, nl)
+}
+
+func (nl *NLSpline) Derivative(t float64) float64 {
+	i, xs, vs, ms := nl.segment(t)
+	switch {
+	case i < 0:
+		return ms[0]
+	case i == len(xs)-1:
+		return ms[i]
+	}
+	h := xs[i+1] - xs[i]
+	s := (t - xs[i]) / h
+	s2 := s * s
+	return ((6*s2-6*s)*(vs[i]-vs[i+1]))/h + (3*s2-4*s+1)*ms[i] + (3*s2-2*s)*ms[i+1]
+}
+
+func (nl *NLSpline) SecondDerivative(t float64) float64 {
+	i, xs, vs, ms := nl.segment(t)
+	if i < 0 || i == len(xs)-1 {
+		return 0
+	}
+	h := xs[i+1] - xs[i]
+	s := (t - xs[i]) / h
+	return ((12*s-6)*(vs[i]-vs[i+1])/h + (6*s-4)*ms[i] + (6*s-2)*ms[i+1]) / h
+}
+
+// FitMonotoneSpline fits a smooth, monotonic spline to noisy (x, y) data. The xs are normalized to
+// [0,1] and the values at n evenly spaced knots found by penalized least squares, minimizing the mean
+// squared residual of their linear interpolation plus smoothing times the sum of the squared second
+// differences of the knot values. The knot values are then made non-decreasing by isotonic
+// regression, as by RepairStops, and normalized so that the first is 0 and the last 1. Descending
+// data should be negated first.
+func FitMonotoneSpline(points [][2]float64, n int, smoothing float64) (*NLSpline, error) {
+	if n < 2 {
+		return nil, invalidParam("n", float64(n), ">= 2")
+	}
+	if !(smoothing >= 0) || math.IsInf(smoothing, 1) {
+		return nil, invalidParam("smoothing", smoothing, "finite and >= 0")
+	}
+	pts := make([][2]float64, 0, len(points))
+	for _, p := range points {
+		if isFinite(p[0]) && isFinite(p[1]) {
+			pts = append(pts, p)
+		}
+	}
+	if len(pts) < 2 {
+		return nil, fmt.Errorf("nonlinear: FitMonotoneSpline needs at least 2 finite points, got %d", len(pts))
+	}
+	slices.SortFunc(pts, func(a, b [2]float64) int { return cmp.Compare(a[0], b[0]) })
+	x0, dx := pts[0][0], pts[len(pts)-1][0]-pts[0][0]
+	if dx == 0 {
+		return nil, fmt.Errorf("nonlinear: FitMonotoneSpline data has no range in x")
+	}
+
+	// Normal equations for the knot values - each point contributes to the two knots either side
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n)
+	}
+	b := make([]float64, n)
+	w := 1 / float64(len(pts))
+	for _, p := range pts {
+		x := (p[0] - x0) / dx * float64(n-1)
+		i := min(int(x), n-2)
+		f := x - float64(i)
+		a[i][i] += w * (1 - f) * (1 - f)
+		a[i][i+1] += w * (1 - f) * f
+		a[i+1][i] += w * (1 - f) * f
+		a[i+1][i+1] += w * f * f
+		b[i] += w * (1 - f) * p[1]
+		b[i+1] += w * f * p[1]
+	}
+	for i := 0; i+2 < n; i++ {
+		d := [3]float64{1, -2, 1}
+		for j := range d {
+			for k := range d {
+				a[i+j][i+k] += smoothing * d[j] * d[k]
+			}
+		}
+	}
+	if !solveLinear(a, b) {
+		return nil, fmt.Errorf("nonlinear: FitMonotoneSpline has knots without data, use fewer knots or smoothing > 0")
+	}
+
+	ss := make([]Stop, n)
+	for i, v := range b {
+		ss[i] = Stop{float64(i) / float64(n-1), v}
+	}
+	ss, _ = RepairStops(ss)
+	v0, dv := ss[0].V, ss[n-1].V-ss[0].V
+	if !(dv > 0) {
+		return nil, fmt.Errorf("nonlinear: FitMonotoneSpline data doesn't rise")
+	}
+	knots := make([][]float64, n)
+	for i, s := range ss {
+		knots[i] = []float64{s.T, (s.V - v0) / dv}
+	}
+	knots[n-1][0], knots[n-1][1] = 1, 1
+	return NewNLSpline(knots), nil
+}
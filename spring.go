@@ -0,0 +1,190 @@
+package nonlinear
+
+import (
+	"fmt"
+	"math"
+)
+
+// springSolve returns the displacement from the rest point and the velocity of a damped harmonic
+// oscillator after time t, starting with displacement x0 and velocity v0. The solution is exact, so
+// the result doesn't depend on how t is split into steps.
+func springSolve(stiffness, damping, mass, x0, v0, t float64) (float64, float64) {
+	if mass <= 0 {
+		mass = 1
+	}
+	w0 := math.Sqrt(stiffness / mass)
+	zeta := damping / (2 * math.Sqrt(stiffness*mass))
+	switch {
+	case w0 == 0:
+		// No spring, just drag
+		if damping == 0 {
+			return x0 + v0*t, v0
+		}
+		k := damping / mass
+		e := math.Exp(-k * t)
+		return x0 + v0*(1-e)/k, v0 * e
+	case zeta < 1:
+		a := zeta * w0
+		wd := w0 * math.Sqrt(1-zeta*zeta)
+		b := (v0 + a*x0) / wd
+		e := math.Exp(-a * t)
+		s, c := math.Sincos(wd * t)
+		return e * (x0*c + b*s), e * (v0*c - (a*b+x0*wd)*s)
+	case zeta == 1:
+		c := v0 + w0*x0
+		e := math.Exp(-w0 * t)
+		return e * (x0 + c*t), e * (v0 - w0*c*t)
+	}
+	// -zeta*w0 ± w0*sqrt(zeta^2-1), with the slow root computed without cancellation
+	q := zeta + math.Sqrt((zeta-1)*(zeta+1))
+	r1, r2 := -w0/q, -w0*q
+	c2 := (v0 - r1*x0) / (r2 - r1)
+	c1 := x0 - c2
+	e1, e2 := math.Exp(r1*t), math.Exp(r2*t)
+	return c1*e1 + c2*e2, r1*c1*e1 + r2*c2*e2
+}
+
+// SpringSettleTime returns the time a spring released at rest takes to come within eps of its target,
+// as a fraction of the initial distance, and stay there.
+func SpringSettleTime(stiffness, damping, mass, eps float64) float64 {
+	if mass <= 0 {
+		mass = 1
+	}
+	if !(stiffness > 0) || !(damping >= 0) || !(eps > 0) {
+		return math.Inf(1)
+	}
+	w0 := math.Sqrt(stiffness / mass)
+	zeta := damping / (2 * math.Sqrt(stiffness*mass))
+	if zeta == 0 {
+		return math.Inf(1)
+	}
+	if zeta < 1 {
+		// When the envelope of the oscillation falls to eps
+		return math.Log(1/(eps*math.Sqrt(1-zeta*zeta))) / (zeta * w0)
+	}
+	// Otherwise the approach is monotonic, so bisect for |x| = eps
+	x := func(t float64) float64 {
+		x, _ := springSolve(stiffness, damping, mass, 1, 0, t)
+		return x
+	}
+	hi := 1 / w0
+	for x(hi) > eps {
+		hi *= 2
+	}
+	lo := 0.0
+	for i := 0; i < 64 && hi-lo > 1e-12*hi; i++ {
+		m := (lo + hi) / 2
+		if x(m) > eps {
+			lo = m
+		} else {
+			hi = m
+		}
+	}
+	return hi
+}
+
+// Spring moves a value towards a target, which may change at any time, as a mass on a damped spring
+// does, so neither the value nor its velocity jump when the target moves. A damping of
+// 2*sqrt(stiffness*mass) is critical, less will overshoot and oscillate. Time is in seconds, as for
+// SmoothDamp.
+type Spring struct {
+	Stiffness, Damping, Mass float64
+
+	value, velocity, target float64
+}
+
+// NewSpring returns a Spring at rest at value.
+func NewSpring(value, stiffness, damping, mass float64) *Spring {
+	return &Spring{Stiffness: stiffness, Damping: damping, Mass: mass, value: value, target: value}
+}
+
+// Value returns the current value.
+func (s *Spring) Value() float64 {
+	return s.value
+}
+
+// Velocity returns the current velocity.
+func (s *Spring) Velocity() float64 {
+	return s.velocity
+}
+
+// Target returns the current target.
+func (s *Spring) Target() float64 {
+	return s.target
+}
+
+// SetTarget changes the target, keeping the current value and velocity.
+func (s *Spring) SetTarget(target float64) {
+	s.target = target
+}
+
+// SetVelocity sets the velocity, e.g. to that of a gesture ending in a fling.
+func (s *Spring) SetVelocity(v float64) {
+	s.velocity = v
+}
+
+// Reset places the value at rest at value, with the target also set to value.
+func (s *Spring) Reset(value float64) {
+	s.value, s.target, s.velocity = value, value, 0
+}
+
+// Update advances the value by dt and returns it.
+func (s *Spring) Update(dt float64) float64 {
+	if dt <= 0 {
+		return s.value
+	}
+	x, v := springSolve(s.Stiffness, s.Damping, s.Mass, s.value-s.target, s.velocity, dt)
+	s.value, s.velocity = s.target+x, v
+	return s.value
+}
+
+// Settled returns true if the value is within eps of the target and the speed is below eps.
+func (s *Spring) Settled(eps float64) bool {
+	return math.Abs(s.value-s.target) <= eps && math.Abs(s.velocity) <= eps
+}
+
+// Curve returns the spring's motion from rest at 0 to the target 1 as an NLSpring lasting until it
+// settles to within 0.1%. It's an error for the spring never to settle, i.e. without stiffness or
+// damping.
+func (s *Spring) Curve() (*NLSpring, error) {
+	d := SpringSettleTime(s.Stiffness, s.Damping, s.Mass, 1e-3)
+	if math.IsInf(d, 1) {
+		return nil, fmt.Errorf("nonlinear: spring with stiffness %g and damping %g never settles", s.Stiffness, s.Damping)
+	}
+	return NewNLSpring(s.Stiffness, s.Damping, s.Mass, d), nil
+}
+
+// NLSpring v = the position of a damped spring released at rest at 0 with its target at 1, after
+// t*Duration seconds. Unless critically or over damped, it overshoots and isn't monotonic -
+// InvTransform returns one of the t for which f(t) = v, found numerically. f(1) is only 1 to within the
+// distance the spring hasn't settled by Duration, see SpringSettleTime and NLExact.
+type NLSpring struct {
+	Stiffness, Damping, Mass, Duration float64
+}
+
+func NewNLSpring(stiffness, damping, mass, duration float64) *NLSpring {
+	return &NLSpring{stiffness, damping, mass, duration}
+}
+
+func (nl *NLSpring) Transform(t float64) float64 {
+	x, _ := springSolve(nl.Stiffness, nl.Damping, nl.Mass, -1, 0, t*nl.Duration)
+	return 1 + x
+}
+
+func (nl *NLSpring) InvTransform(v float64) float64 {
+	return bsInv(v, nl)
+}
+
+func (nl *NLSpring) Derivative(t float64) float64 {
+	_, v := springSolve(nl.Stiffness, nl.Damping, nl.Mass, -1, 0, t*nl.Duration)
+	return v * nl.Duration
+}
+
+func (nl *NLSpring) SecondDerivative(t float64) float64 {
+	x, v := springSolve(nl.Stiffness, nl.Damping, nl.Mass, -1, 0, t*nl.Duration)
+	m := nl.Mass
+	if m <= 0 {
+		m = 1
+	}
+	return -(nl.Stiffness*x + nl.Damping*v) / m * nl.Duration * nl.Duration
+}
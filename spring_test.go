@@ -0,0 +1,39 @@
+package nonlinear
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSpringCurve(t *testing.T) {
+	for _, s := range []*Spring{NewSpring(0, 100, 0, 1), NewSpring(0, 0, 10, 1), NewSpring(0, math.NaN(), 10, 1)} {
+		if _, err := s.Curve(); err == nil {
+			t.Errorf("Curve of spring %g, %g succeeded, want an error", s.Stiffness, s.Damping)
+		}
+	}
+	f, err := NewSpring(0, 170, 26, 1).Curve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := f.Transform(1); math.Abs(v-1) > 1e-3 {
+		t.Errorf("f(1) = %g, want within 1e-3 of 1", v)
+	}
+}
+
+func TestSpringOverdamped(t *testing.T) {
+	// The slow root is -1e-9, which -zeta*w0 + w0*sqrt(zeta^2-1) rounds to 0
+	s := NewSpring(0, 1, 1e9, 1)
+	f, err := s.Curve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, want := f.Duration, 1e9*math.Log(1e3); math.Abs(d/want-1) > 1e-6 {
+		t.Errorf("Duration = %g, want %g", d, want)
+	}
+	for _, tt := range []float64{0, 0.5, 1} {
+		want := 1 - math.Pow(1e-3, tt)
+		if v := f.Transform(tt); math.Abs(v-want) > 1e-6 {
+			t.Errorf("f(%g) = %g, want %g", tt, v, want)
+		}
+	}
+}
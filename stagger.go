@@ -0,0 +1,38 @@
+package nonlinear
+
+import (
+	"math"
+	"time"
+)
+
+// Stagger returns n tweens from 0 to 1, each of length per and eased by curve, for cascading the
+// entrance of the items of a list. Each starts overlap before the one before it ends, so item i is
+// delayed by i*(per-overlap). With overlap >= per, they all start together.
+func Stagger(n int, per, overlap time.Duration, curve NonLinear) []*Tween {
+	return StaggerNL(n, per, overlap, curve, nil)
+}
+
+// StaggerNL is Stagger with the delays shaped by spread, e.g. with an ease-in the first items follow
+// each other closely and the later ones more slowly. Item i is delayed by spread(i/(n-1)) of the last
+// item's delay. With spread nil, the delays are evenly spaced, as for Stagger.
+func StaggerNL(n int, per, overlap time.Duration, curve, spread NonLinear) []*Tween {
+	if n <= 0 {
+		return nil
+	}
+	step := max(per-overlap, 0)
+	last := time.Duration(n-1) * step
+	tws := make([]*Tween, n)
+	for i := range tws {
+		tw := NewTween(per, 0, 1, curve)
+		switch {
+		case spread == nil:
+			tw.Delay = time.Duration(i) * step
+		case n > 1:
+			// Overshooting spreads can't start items before the first
+			d := math.Round(NLerp(float64(i)/float64(n-1), 0, float64(last), spread))
+			tw.Delay = time.Duration(max(d, 0))
+		}
+		tws[i] = tw
+	}
+	return tws
+}
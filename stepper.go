@@ -0,0 +1,74 @@
+package nonlinear
+
+import "time"
+
+// Updater is advanced by frame times, as are Tween, Timeline, Animator and Stepper.
+type Updater interface {
+	Update(dt time.Duration)
+}
+
+// Stepper drives an Updater in fixed steps, whatever the frame times passed to Update, so that
+// animations and the logic using them play out the same at any frame rate. Frame time not yet
+// stepped is carried over to the next Update, and Alpha gives how far between steps the frame falls,
+// for interpolating what's drawn. With Step <= 0, frame times are passed on as they are.
+type Stepper struct {
+	Step     time.Duration
+	MaxSteps int // Steps per Update before the remaining time is dropped, or 0 for no limit
+
+	target Updater
+	acc    time.Duration
+}
+
+// NewStepper returns a Stepper advancing u in steps of step.
+func NewStepper(u Updater, step time.Duration) *Stepper {
+	return &Stepper{Step: step, target: u}
+}
+
+// Update adds dt to the time carried over and advances the target by as many steps as fit. If
+// MaxSteps is reached, the time left over is dropped, so a slow frame can't cause more work in the
+// next.
+func (s *Stepper) Update(dt time.Duration) {
+	s.Advance(dt)
+}
+
+// Advance is Update, returning the number of steps taken, e.g. to run game logic as often.
+func (s *Stepper) Advance(dt time.Duration) int {
+	if s.Step <= 0 {
+		if dt <= 0 {
+			return 0
+		}
+		s.target.Update(dt)
+		return 1
+	}
+	s.acc = addDuration(s.acc, max(dt, 0))
+	n := 0
+	for s.acc >= s.Step {
+		if s.MaxSteps > 0 && n == s.MaxSteps {
+			s.acc %= s.Step
+			break
+		}
+		s.target.Update(s.Step)
+		s.acc -= s.Step
+		n++
+	}
+	return n
+}
+
+// Alpha returns the time carried over as a fraction of Step, in [0,1).
+func (s *Stepper) Alpha() float64 {
+	if s.Step <= 0 {
+		return 0
+	}
+	return float64(s.acc) / float64(s.Step)
+}
+
+// Lerp returns the value to draw for one which was prev before the last step and is now cur. What's
+// drawn then lags by up to a step, but moves smoothly.
+func (s *Stepper) Lerp(prev, cur float64) float64 {
+	return prev + (cur-prev)*s.Alpha()
+}
+
+// Reset drops the time carried over.
+func (s *Stepper) Reset() {
+	s.acc = 0
+}
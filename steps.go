@@ -0,0 +1,73 @@
+package nonlinear
+
+import "math"
+
+// StepPosition determines where the jumps of NLSteps occur, as for CSS steps().
+type StepPosition int
+
+const (
+	JumpEnd   StepPosition = iota // Jumps at the end of each interval, the last at t=1
+	JumpStart                     // Jumps at the start of each interval, the first at t=0
+	JumpNone                      // No jump at either end
+	JumpBoth                      // Jumps at both t=0 and t=1
+)
+
+// NLSteps v = step(t) where [0,1] is divided into N equal intervals, as for CSS steps().
+type NLSteps struct {
+	N        int
+	Position StepPosition
+}
+
+func NewNLSteps(n int, pos StepPosition) *NLSteps {
+	return &NLSteps{n, pos}
+}
+
+// jumps returns the number of distinct output levels less one.
+func (nl *NLSteps) jumps() int {
+	switch nl.Position {
+	case JumpNone:
+		return nl.N - 1
+	case JumpBoth:
+		return nl.N + 1
+	}
+	return nl.N
+}
+
+func (nl *NLSteps) Transform(t float64) float64 {
+	step := int(math.Floor(t * float64(nl.N)))
+	if nl.Position == JumpStart || nl.Position == JumpBoth {
+		step++
+	}
+	j := nl.jumps()
+	if t >= 0 && step < 0 {
+		step = 0
+	}
+	if t <= 1 && step > j {
+		step = j
+	}
+	return float64(step) / float64(j)
+}
+
+// InvTransform returns the smallest t at which the output reaches v.
+func (nl *NLSteps) InvTransform(v float64) float64 {
+	step := int(math.Ceil(v * float64(nl.jumps())))
+	if nl.Position == JumpStart || nl.Position == JumpBoth {
+		step--
+	}
+	t := float64(step) / float64(nl.N)
+	if t < 0 {
+		return 0
+	}
+	if t > 1 {
+		return 1
+	}
+	return t
+}
+
+func (nl *NLSteps) Derivative(t float64) float64 {
+	return 0
+}
+
+func (nl *NLSteps) SecondDerivative(t float64) float64 {
+	return 0
+}
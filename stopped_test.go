@@ -0,0 +1,71 @@
+package nonlinear
+
+import "testing"
+
+func TestNLStopped(t *testing.T) {
+	tests := []struct {
+		name  string
+		stops [][]float64
+		t, v  float64
+	}{
+		// Implicit end stops
+		{"implicit ends", [][]float64{{0.5, 0.25}}, 0, 0},
+		{"implicit ends", [][]float64{{0.5, 0.25}}, 0.25, 0.125},
+		{"implicit ends", [][]float64{{0.5, 0.25}}, 0.75, 0.625},
+		{"implicit ends", [][]float64{{0.5, 0.25}}, 1, 1},
+		{"no stops", nil, 0.3, 0.3},
+		{"explicit ends", [][]float64{{0, 0.5}, {1, 0.5}}, 0.3, 0.5},
+
+		// Binary search over many segments
+		{"many", [][]float64{{0.1, 0.2}, {0.2, 0.3}, {0.3, 0.35}, {0.4, 0.5}, {0.5, 0.55}, {0.6, 0.7}, {0.7, 0.8}, {0.8, 0.85}, {0.9, 0.95}}, 0.35, 0.425},
+		{"many", [][]float64{{0.1, 0.2}, {0.2, 0.3}, {0.3, 0.35}, {0.4, 0.5}, {0.5, 0.55}, {0.6, 0.7}, {0.7, 0.8}, {0.8, 0.85}, {0.9, 0.95}}, 0.1, 0.2},
+		{"many", [][]float64{{0.1, 0.2}, {0.2, 0.3}, {0.3, 0.35}, {0.4, 0.5}, {0.5, 0.55}, {0.6, 0.7}, {0.7, 0.8}, {0.8, 0.85}, {0.9, 0.95}}, 0.05, 0.1},
+		{"many", [][]float64{{0.1, 0.2}, {0.2, 0.3}, {0.3, 0.35}, {0.4, 0.5}, {0.5, 0.55}, {0.6, 0.7}, {0.7, 0.8}, {0.8, 0.85}, {0.9, 0.95}}, 0.95, 0.975},
+
+		// Jumps are right-continuous
+		{"jump", [][]float64{{0.5, 0.2}, {0.5, 0.8}}, 0.25, 0.1},
+		{"jump", [][]float64{{0.5, 0.2}, {0.5, 0.8}}, 0.5, 0.8},
+		{"triple jump", [][]float64{{0.5, 0.2}, {0.5, 0.9}, {0.5, 0.8}}, 0.5, 0.8},
+		{"jump at 0", [][]float64{{0, 0}, {0, 0.5}}, 0, 0.5},
+		{"jump at 1", [][]float64{{1, 0.5}, {1, 1}}, 1, 1},
+		{"jump at 1", [][]float64{{1, 0.5}, {1, 1}}, 0.5, 0.25},
+
+		// Extrapolation along the end segments
+		{"extrapolate", [][]float64{{0.5, 0.25}}, -0.5, -0.25},
+		{"extrapolate", [][]float64{{0.5, 0.25}}, 1.5, 1.75},
+		{"extrapolate jump", [][]float64{{0, 0}, {0, 0.5}}, -0.5, 0},
+		{"extrapolate jump", [][]float64{{1, 0.5}, {1, 1}}, 1.5, 1},
+	}
+	for _, tt := range tests {
+		f := NewNLStopped(tt.stops)
+		if v := f.Transform(tt.t); !approx(v, tt.v) {
+			t.Errorf("%s: Transform(%g) = %g, want %g", tt.name, tt.t, v, tt.v)
+		}
+		// Curves not made by NewNLStopped build their tables on the fly
+		g := &NLStopped{Stops: tt.stops}
+		if v := g.Transform(tt.t); !approx(v, tt.v) {
+			t.Errorf("%s: unprepared Transform(%g) = %g, want %g", tt.name, tt.t, v, tt.v)
+		}
+	}
+}
+
+func TestNLStoppedDerivative(t *testing.T) {
+	f := NewNLStopped([][]float64{{0.5, 0.25}, {0.5, 0.5}})
+	tests := []struct{ t, d float64 }{
+		{-0.5, 0.5},
+		{0.25, 0.5},
+		{0.5, 1}, // The slope of the segment starting at a stop
+		{0.75, 1},
+		{1.5, 1},
+	}
+	for _, tt := range tests {
+		if d := f.Derivative(tt.t); !approx(d, tt.d) {
+			t.Errorf("Derivative(%g) = %g, want %g", tt.t, d, tt.d)
+		}
+	}
+}
+
+func approx(a, b float64) bool {
+	d := a - b
+	return d < 1e-12 && d > -1e-12
+}
@@ -0,0 +1,45 @@
+package nonlinear
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SVGPath returns SVG path data for the graph of f drawn with samples line segments in the unit box.
+// The y axis is flipped so that the path plots v upwards when used with viewBox="0 0 1 1".
+func SVGPath(f NonLinear, samples int) string {
+	if samples < 1 {
+		samples = 1
+	}
+	var sb strings.Builder
+	for i := 0; i <= samples; i++ {
+		t := float64(i) / float64(samples)
+		if i == 0 {
+			sb.WriteString("M")
+		} else {
+			sb.WriteString(" L")
+		}
+		sb.WriteString(svgNumber(t))
+		sb.WriteByte(' ')
+		sb.WriteString(svgNumber(1 - f.Transform(t)))
+	}
+	return sb.String()
+}
+
+// svgNumber formats v to 6 decimal places without trailing zeros.
+func svgNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e6)/1e6, 'f', -1, 64)
+}
+
+// WriteSVG writes a complete SVG document, size pixels square, plotting f inside a light gray unit box.
+func WriteSVG(w io.Writer, f NonLinear, samples, size int) error {
+	_, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="-0.1 -0.1 1.2 1.2">
+  <rect x="0" y="0" width="1" height="1" fill="#d3d3d3"/>
+  <path d="%s" fill="none" stroke="black" stroke-width="0.008"/>
+</svg>
+`, size, size, SVGPath(f, samples))
+	return err
+}
@@ -0,0 +1,59 @@
+package nonlinear
+
+import "math"
+
+// NLTable approximates f with tables of evenly spaced samples of its transform and inverse,
+// interpolated linearly, which is much cheaper to evaluate than curves built from exp, log or pow.
+// The inverse is least accurate where f is flat, and so its inverse steep.
+type NLTable struct {
+	F  NonLinear
+	Vs []float64 // f(i/(n-1))
+	Ts []float64 // f^-1(i/(n-1))
+}
+
+// NewNLTable samples f and its inverse at n points each. n < 2 is treated as 2.
+func NewNLTable(f NonLinear, n int) *NLTable {
+	if n < 2 {
+		n = 2
+	}
+	vs, ts := make([]float64, n), make([]float64, n)
+	for i := range vs {
+		x := float64(i) / float64(n-1)
+		vs[i] = f.Transform(x)
+		ts[i] = f.InvTransform(x)
+	}
+	return &NLTable{f, vs, ts}
+}
+
+// lookup interpolates tab, sampled evenly over [0,1], at x. x is clamped to [0,1].
+func lookup(tab []float64, x float64) float64 {
+	n := len(tab) - 1
+	x = math.Max(0, math.Min(1, x)) * float64(n)
+	i := int(x)
+	if i >= n {
+		return tab[n]
+	}
+	x -= float64(i)
+	return (1-x)*tab[i] + x*tab[i+1]
+}
+
+func (nl *NLTable) Transform(t float64) float64 {
+	return lookup(nl.Vs, t)
+}
+
+func (nl *NLTable) InvTransform(v float64) float64 {
+	return lookup(nl.Ts, v)
+}
+
+func (nl *NLTable) Derivative(t float64) float64 {
+	n := len(nl.Vs) - 1
+	i := int(math.Max(0, math.Min(1, t)) * float64(n))
+	if i >= n {
+		i = n - 1
+	}
+	return (nl.Vs[i+1] - nl.Vs[i]) * float64(n)
+}
+
+func (nl *NLTable) SecondDerivative(t float64) float64 {
+	return 0
+}
@@ -0,0 +1,566 @@
+package nonlinear
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FormatCurve returns the canonical Parse expression for f, e.g. "omt(lame(2,0.5))". Curves that
+// aren't known to the registry are formatted with their Go type and can't be parsed.
+func FormatCurve(f NonLinear) string {
+	var sb strings.Builder
+	formatCurve(&sb, f)
+	return sb.String()
+}
+
+func formatCurve(sb *strings.Builder, f NonLinear) bool {
+	s, ok := f.(specifier)
+	if !ok {
+		fmt.Fprintf(sb, "%T", f)
+		return false
+	}
+	sp := s.spec()
+	sb.WriteString(sp.Name)
+	if len(sp.Params) == 0 && len(sp.Curves) == 0 {
+		return true
+	}
+	info, _ := Lookup(sp.Name)
+	pairs := info.VarParams && len(info.Params) == 2
+	sb.WriteByte('(')
+	ok = true
+	for i, c := range sp.Curves {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		ok = formatCurve(sb, c) && ok
+	}
+	for i, p := range sp.Params {
+		switch {
+		case pairs && i%2 == 1:
+			sb.WriteByte(':')
+		case i > 0 || len(sp.Curves) > 0:
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatFloat(p, 'g', -1, 64))
+	}
+	sb.WriteByte(')')
+	return ok
+}
+
+// MarshalCurveText returns the Parse expression for f, or an error if it contains curves unknown to
+// the registry.
+func MarshalCurveText(f NonLinear) ([]byte, error) {
+	var sb strings.Builder
+	if !formatCurve(&sb, f) {
+		return nil, fmt.Errorf("nonlinear: %s can't be serialized", sb.String())
+	}
+	return []byte(sb.String()), nil
+}
+
+// unmarshalTextInto parses text into the curve pointed to by dst, which must be of the same type.
+func unmarshalTextInto(dst NonLinear, text []byte) error {
+	f, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	dv, fv := reflect.ValueOf(dst), reflect.ValueOf(f)
+	if dv.Type() != fv.Type() {
+		return fmt.Errorf("nonlinear: can't unmarshal %T into %T", f, dst)
+	}
+	dv.Elem().Set(fv.Elem())
+	return nil
+}
+
+func (nl *NLLinear) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLLinear) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLLinear) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLSquare) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLSquare) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLSquare) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLCube) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLCube) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLCube) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLExponential) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLExponential) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLExponential) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLLogarithmic) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLLogarithmic) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLLogarithmic) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLSin) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLSin) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLSin) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLSin1) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLSin1) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLSin1) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLSin2) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLSin2) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLSin2) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLCircle1) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLCircle1) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLCircle1) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLCircle2) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLCircle2) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLCircle2) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLLame) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLLame) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLLame) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLCatenary) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLCatenary) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLCatenary) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLGauss) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLGauss) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLGauss) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLLogistic) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLLogistic) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLLogistic) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLP3) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLP3) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLP3) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLP5) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLP5) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLP5) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLCompound) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLCompound) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLCompound) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLOmt) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLOmt) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLOmt) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLStopped) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLStopped) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLStopped) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLSpline) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLSpline) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLSpline) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLPolynomial) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLPolynomial) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLPolynomial) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLSpring) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLSpring) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLSpring) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLConditional) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLConditional) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLConditional) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLNormalized) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLNormalized) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLNormalized) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLExact) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLExact) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLExact) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLDomain) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLDomain) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLDomain) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLInOut) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLInOut) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLInOut) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLDescend) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLDescend) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLDescend) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLSoftClamp) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLSoftClamp) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLSoftClamp) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLConstantSpeed) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLConstantSpeed) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLConstantSpeed) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLInverse) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLInverse) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLInverse) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLCubicBezier) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLCubicBezier) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLCubicBezier) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLSteps) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLSteps) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLSteps) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLPower) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLPower) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLPower) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLBack) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLBack) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLBack) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLElastic) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLElastic) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLElastic) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLBounce) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLBounce) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLBounce) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLWindow) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLWindow) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLWindow) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLTable) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLTable) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLTable) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLCached) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLCached) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLCached) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
+
+func (nl *NLInvTable) String() string {
+	return FormatCurve(nl)
+}
+
+func (nl *NLInvTable) MarshalText() ([]byte, error) {
+	return MarshalCurveText(nl)
+}
+
+func (nl *NLInvTable) UnmarshalText(text []byte) error {
+	return unmarshalTextInto(nl, text)
+}
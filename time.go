@@ -0,0 +1,34 @@
+package nonlinear
+
+import (
+	"math"
+	"time"
+)
+
+// NLerpDuration is NLerp for durations. The result is rounded to the nearest nanosecond.
+func NLerpDuration(t float64, start, end time.Duration, f NonLinear) time.Duration {
+	if t < 0 {
+		return start
+	}
+	if t > 1 {
+		return end
+	}
+	return start + time.Duration(math.Round(f.Transform(t)*float64(end-start)))
+}
+
+// NLerpTime is NLerp for times. The result is rounded to the nearest nanosecond and has the location
+// of start.
+func NLerpTime(t float64, start, end time.Time, f NonLinear) time.Time {
+	if t < 0 {
+		return start
+	}
+	if t > 1 {
+		return end
+	}
+	return start.Add(time.Duration(math.Round(f.Transform(t) * float64(end.Sub(start)))))
+}
+
+// InvNLerpDuration is InvNLerp for durations.
+func InvNLerpDuration(v, start, end time.Duration, f NonLinear) float64 {
+	return InvNLerp(float64(v-start), 0, float64(end-start), f)
+}
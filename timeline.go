@@ -0,0 +1,158 @@
+package nonlinear
+
+import "time"
+
+// Timeline plays animations, tweens or other timelines, each starting at its own offset from the start
+// of the timeline, e.g. a fade in, a hold and then a slide and fade out together
+//
+//	var tl Timeline
+//	tl.Append(fadeIn, 0).Append(slideOut, hold).Join(fadeOut, 0)
+//
+// The zero value is an empty timeline. Seeking a timeline seeks every animation in it, so the state
+// of the whole set reflects the absolute time, whatever the order of updates. Looping a timeline loops
+// everything in it together, with LoopPingPong playing the whole set backwards.
+type Timeline struct {
+	Repeat int      // Plays after the first, or RepeatForever
+	Mode   LoopMode // How repeats are played
+
+	// Optional hooks, called by Update and Seek after the animations have been seeked
+	OnStart    func()         // When the timeline starts
+	OnUpdate   func()         // When the time changes
+	OnLoop     func(play int) // When a repeat starts, with its index from 1
+	OnComplete func()         // When the timeline becomes done
+
+	entries            []timelineEntry
+	last               time.Duration // Start of the most recently added animation
+	elapsed            time.Duration
+	paused, reversed   bool
+	started, completed bool
+	play               int
+}
+
+type timelineEntry struct {
+	start time.Duration
+	a     Animation
+}
+
+// Append adds a to start offset after the end of the timeline so far. A negative offset overlaps
+// the end, with a starting no earlier than the start of the timeline.
+func (tl *Timeline) Append(a Animation, offset time.Duration) *Timeline {
+	return tl.Insert(tl.period()+offset, a)
+}
+
+// Join adds a to start offset after the start of the most recently added animation, to play
+// alongside it.
+func (tl *Timeline) Join(a Animation, offset time.Duration) *Timeline {
+	return tl.Insert(tl.last+offset, a)
+}
+
+// Insert adds a to start at the given time from the start of the timeline. The animation is seeked to
+// the timeline's current time.
+func (tl *Timeline) Insert(start time.Duration, a Animation) *Timeline {
+	start = max(start, 0)
+	tl.entries = append(tl.entries, timelineEntry{start, a})
+	tl.last = start
+	tl.Seek(tl.elapsed)
+	return tl
+}
+
+// period returns the length of a single play, from the start until the last animation is done.
+func (tl *Timeline) period() time.Duration {
+	var d time.Duration
+	for _, e := range tl.entries {
+		d = max(d, addDuration(e.start, e.a.TotalDuration()))
+	}
+	return d
+}
+
+// TotalDuration returns the length of every play of the timeline, or Forever.
+func (tl *Timeline) TotalDuration() time.Duration {
+	return loopDuration(tl.period(), tl.Repeat)
+}
+
+// Update advances the timeline by dt, or takes it back by dt if reversed, unless paused.
+func (tl *Timeline) Update(dt time.Duration) {
+	if tl.paused {
+		return
+	}
+	if tl.reversed {
+		dt = -dt
+	}
+	tl.Seek(tl.elapsed + dt)
+}
+
+// Pause stops Update from changing the timeline until Resume is called. Seek still applies.
+func (tl *Timeline) Pause() {
+	tl.paused = true
+}
+
+// Resume undoes Pause.
+func (tl *Timeline) Resume() {
+	tl.paused = false
+}
+
+// Paused returns true if the timeline is paused.
+func (tl *Timeline) Paused() bool {
+	return tl.paused
+}
+
+// Reverse changes the direction of play. As every animation is seeked to the timeline's time, played
+// backwards each retraces its curve, and the timeline is done once back at its start.
+func (tl *Timeline) Reverse() {
+	tl.reversed = !tl.reversed
+}
+
+// Reversed returns true if the timeline is playing backwards.
+func (tl *Timeline) Reversed() bool {
+	return tl.reversed
+}
+
+// Seek sets the time from the start of the timeline to t, kept within its duration, and seeks each
+// animation to its own time within the current play.
+func (tl *Timeline) Seek(t time.Duration) {
+	prev := tl.elapsed
+	tl.elapsed = min(max(t, 0), tl.TotalDuration())
+	u, play := loopTime(tl.elapsed, tl.period(), tl.Repeat, tl.Mode)
+	for _, e := range tl.entries {
+		e.a.Seek(u - e.start)
+	}
+
+	started := tl.elapsed > 0
+	if started && !tl.started && !tl.reversed && tl.OnStart != nil {
+		tl.OnStart()
+	}
+	tl.started = started
+	if play != tl.play && play > 0 && tl.OnLoop != nil {
+		tl.OnLoop(play)
+	}
+	tl.play = play
+	if tl.elapsed != prev && tl.OnUpdate != nil {
+		tl.OnUpdate()
+	}
+	done := tl.Done()
+	if done && !tl.completed && tl.OnComplete != nil {
+		tl.OnComplete()
+	}
+	tl.completed = done
+}
+
+// Elapsed returns the time from the start of the timeline.
+func (tl *Timeline) Elapsed() time.Duration {
+	return tl.elapsed
+}
+
+// Done returns true once every animation is done, or if reversed, once back at the start.
+func (tl *Timeline) Done() bool {
+	if tl.reversed {
+		return tl.elapsed <= 0
+	}
+	return tl.elapsed >= tl.TotalDuration()
+}
+
+// Reset seeks the timeline back to its start, playing forwards and not paused. The hooks for starting
+// and completing will be called again.
+func (tl *Timeline) Reset() {
+	tl.paused, tl.reversed = false, false
+	tl.Seek(0)
+	tl.started, tl.completed, tl.play = false, false, 0
+}
@@ -0,0 +1,215 @@
+package nonlinear
+
+import "time"
+
+// Animation is implemented by Tween and Timeline, allowing timelines to be nested.
+type Animation interface {
+	Update(dt time.Duration)      // Advance by dt
+	Seek(t time.Duration)         // Jump to t from the start
+	TotalDuration() time.Duration // Time from the start until done
+	Done() bool
+}
+
+// Tween moves a value from From to To over Duration, starting after Delay, eased by Curve, or
+// linearly if Curve is nil. It keeps only the elapsed time, so driving it doesn't allocate.
+type Tween struct {
+	Duration time.Duration
+	Delay    time.Duration
+	Curve    NonLinear
+	From, To float64
+	Repeat   int      // Plays after the first, or RepeatForever. The delay isn't repeated.
+	Mode     LoopMode // How repeats are played
+
+	// Optional hooks, called by Update and Seek
+	OnStart    func()          // When the first play starts, after the delay
+	OnUpdate   func(v float64) // When the elapsed time changes, with the new value
+	OnLoop     func(play int)  // When a repeat starts, with its index from 1
+	OnComplete func()          // When the tween becomes done
+
+	elapsed            time.Duration
+	paused, reversed   bool
+	started, completed bool
+	play               int
+}
+
+// NewTween returns a Tween from from to to over d, eased by curve.
+func NewTween(d time.Duration, from, to float64, curve NonLinear) *Tween {
+	return &Tween{Duration: d, Curve: curve, From: from, To: to}
+}
+
+// Update advances the tween by dt, or takes it back by dt if reversed, unless paused. The elapsed time
+// is kept within the delay and duration.
+func (tw *Tween) Update(dt time.Duration) {
+	if tw.paused {
+		return
+	}
+	if tw.reversed {
+		dt = -dt
+	}
+	tw.Seek(tw.elapsed + dt)
+}
+
+// Pause stops Update from changing the tween until Resume is called. Seek still applies.
+func (tw *Tween) Pause() {
+	tw.paused = true
+}
+
+// Resume undoes Pause.
+func (tw *Tween) Resume() {
+	tw.paused = false
+}
+
+// Paused returns true if the tween is paused.
+func (tw *Tween) Paused() bool {
+	return tw.paused
+}
+
+// Reverse changes the direction of play. Played backwards, the value retraces the curve from where it
+// is back to From, which is the same as easing from To to From with the curve's mirror (see NLOmt),
+// and the tween is done once it's back at the end of the delay.
+func (tw *Tween) Reverse() {
+	tw.reversed = !tw.reversed
+}
+
+// Reversed returns true if the tween is playing backwards.
+func (tw *Tween) Reversed() bool {
+	return tw.reversed
+}
+
+// Seek sets the elapsed time to t, kept within the delay and duration.
+func (tw *Tween) Seek(t time.Duration) {
+	prev := tw.elapsed
+	tw.elapsed = min(max(t, 0), tw.TotalDuration())
+	tw.hooks(prev)
+}
+
+// hooks calls those hooks whose events have happened since the elapsed time was prev.
+func (tw *Tween) hooks(prev time.Duration) {
+	started := tw.elapsed > tw.Delay || tw.Duration <= 0 && tw.elapsed >= tw.Delay
+	if started && !tw.started && !tw.reversed && tw.OnStart != nil {
+		tw.OnStart()
+	}
+	tw.started = started
+	play := 0
+	if started {
+		_, play = loopTime(tw.elapsed-tw.Delay, tw.Duration, tw.Repeat, tw.Mode)
+	}
+	if play != tw.play && play > 0 && tw.OnLoop != nil {
+		tw.OnLoop(play)
+	}
+	tw.play = play
+	if tw.elapsed != prev && tw.OnUpdate != nil {
+		tw.OnUpdate(tw.Value())
+	}
+	done := tw.Done()
+	if done && !tw.completed && tw.OnComplete != nil {
+		tw.OnComplete()
+	}
+	tw.completed = done
+}
+
+// TotalDuration returns the delay plus the duration of every play, or Forever.
+func (tw *Tween) TotalDuration() time.Duration {
+	return addDuration(tw.Delay, loopDuration(tw.Duration, tw.Repeat))
+}
+
+// Elapsed returns the time since the tween was started or reset, including the delay.
+func (tw *Tween) Elapsed() time.Duration {
+	return tw.elapsed
+}
+
+// Progress returns the fraction of the current play's duration that has passed, 0 during the delay
+// and 1 once done, counting down during the backwards plays of LoopPingPong.
+func (tw *Tween) Progress() float64 {
+	t := tw.elapsed - tw.Delay
+	if t < 0 {
+		return 0
+	}
+	if tw.Duration <= 0 {
+		if tw.Mode == LoopPingPong && tw.Repeat%2 == 1 {
+			return 0
+		}
+		return 1
+	}
+	u, _ := loopTime(t, tw.Duration, tw.Repeat, tw.Mode)
+	return float64(u) / float64(tw.Duration)
+}
+
+// Value returns the current value. For curves from (0,0) to (1,1), this is From during the delay and
+// To once done.
+func (tw *Tween) Value() float64 {
+	f := tw.Curve
+	if f == nil {
+		f = &NLLinear{}
+	}
+	return NLerp(tw.Progress(), tw.From, tw.To, f)
+}
+
+// Velocity returns the rate of change of the value per second, 0 during the delay and once done.
+func (tw *Tween) Velocity() float64 {
+	t := tw.elapsed - tw.Delay
+	if t < 0 || tw.Duration <= 0 || tw.Done() {
+		return 0
+	}
+	f := tw.Curve
+	if f == nil {
+		f = &NLLinear{}
+	}
+	u, k := loopTime(t, tw.Duration, tw.Repeat, tw.Mode)
+	v := Derivative(f, float64(u)/float64(tw.Duration)) * (tw.To - tw.From) / tw.Duration.Seconds()
+	if tw.Mode == LoopPingPong && k%2 == 1 {
+		v = -v
+	}
+	if tw.reversed {
+		v = -v
+	}
+	return v
+}
+
+// Retarget changes To mid-flight without the value or its velocity jumping. The tween starts a new
+// play from the current value, eased by a cubic NLPolynomial starting at the current velocity and
+// ending with the old curve's slope at 1, so an ease-out is kept. The play is forwards, the delay isn't
+// replayed and repeats are dropped. During the delay, only To is changed.
+func (tw *Tween) Retarget(to float64) {
+	if tw.elapsed <= tw.Delay && !tw.reversed {
+		tw.To = to
+		return
+	}
+	f := tw.Curve
+	if f == nil {
+		f = &NLLinear{}
+	}
+	x, v := tw.Value(), tw.Velocity()
+	m0, m1 := 0.0, Derivative(f, 1)
+	if d := to - x; d != 0 {
+		m0 = v * tw.Duration.Seconds() / d
+	}
+	if !isFinite(m0) {
+		m0 = 0
+	}
+	if !isFinite(m1) {
+		m1 = 0
+	}
+	if c, err := Constrain().Slope(0, m0).Slope(1, m1).Polynomial(); err == nil {
+		tw.Curve = c
+	}
+	tw.From, tw.To = x, to
+	tw.Repeat, tw.reversed = 0, false
+	tw.elapsed, tw.play, tw.completed = tw.Delay, 0, false
+}
+
+// Done returns true once the delay and every play have passed, or if reversed, once back at the start
+// of the first play.
+func (tw *Tween) Done() bool {
+	if tw.reversed {
+		return tw.elapsed <= tw.Delay
+	}
+	return tw.elapsed >= tw.TotalDuration()
+}
+
+// Reset returns the tween to its start, before the delay, playing forwards and not paused. No hooks
+// are called, and those for starting and completing will be called again.
+func (tw *Tween) Reset() {
+	tw.elapsed, tw.paused, tw.reversed = 0, false, false
+	tw.started, tw.completed, tw.play = false, false, 0
+}
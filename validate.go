@@ -0,0 +1,101 @@
+package nonlinear
+
+import (
+	"fmt"
+	"math"
+)
+
+// Tolerances used by Report.Valid.
+const (
+	EndpointTolerance  = 1e-9
+	RoundTripTolerance = 1e-4
+)
+
+// Report describes how well a function conforms to the NonLinear contract.
+type Report struct {
+	Samples       int
+	V0, V1        float64 // f(0) and f(1)
+	Monotonic     bool    // f is non-decreasing at the sample points
+	DecreaseT     float64 // First t at which f decreases, if not Monotonic
+	Finite        bool    // All sampled values are finite
+	MaxRoundTrip  float64 // Maximum |InvTransform(Transform(t)) - t|
+	MaxRoundTripT float64 // t at which MaxRoundTrip occurs
+}
+
+// Validate samples f at samples+1 evenly spaced points in [0,1] and checks that f(0) = 0, f(1) = 1,
+// f is monotonic non-decreasing and the inverse round trips.
+func Validate(f NonLinear, samples int) *Report {
+	if samples < 1 {
+		samples = 1
+	}
+	r := &Report{
+		Samples:   samples,
+		V0:        f.Transform(0),
+		V1:        f.Transform(1),
+		Monotonic: true,
+		DecreaseT: -1,
+		Finite:    true,
+	}
+	pv := r.V0
+	for i := 0; i <= samples; i++ {
+		t := float64(i) / float64(samples)
+		v := f.Transform(t)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			r.Finite = false
+		}
+		if v < pv && r.Monotonic {
+			r.Monotonic = false
+			r.DecreaseT = t
+		}
+		pv = v
+	}
+	r.MaxRoundTrip, _, r.MaxRoundTripT = RoundTripError(f, samples)
+	return r
+}
+
+// RoundTripError returns the maximum and mean of |InvTransform(Transform(t)) - t| over samples+1
+// evenly spaced t in [0,1], and the t at which the maximum occurs. If the error is NaN at any t, max
+// and mean are NaN and worstT is the first such t. samples < 1 is treated as 1.
+func RoundTripError(f NonLinear, samples int) (max, mean, worstT float64) {
+	if samples < 1 {
+		samples = 1
+	}
+	sum := 0.0
+	for i := 0; i <= samples; i++ {
+		t := float64(i) / float64(samples)
+		d := math.Abs(f.InvTransform(f.Transform(t)) - t)
+		sum += d
+		switch {
+		case math.IsNaN(max):
+		case math.IsNaN(d) || d > max:
+			max, worstT = d, t
+		}
+	}
+	return max, sum / float64(samples+1), worstT
+}
+
+// Valid returns true if the report passes all checks, using EndpointTolerance and RoundTripTolerance.
+func (r *Report) Valid() bool {
+	return len(r.Errors()) == 0
+}
+
+// Errors returns a description of each failed check.
+func (r *Report) Errors() []string {
+	var errs []string
+	if !(math.Abs(r.V0) <= EndpointTolerance) {
+		errs = append(errs, fmt.Sprintf("f(0) = %g, expected 0", r.V0))
+	}
+	if !(math.Abs(r.V1-1) <= EndpointTolerance) {
+		errs = append(errs, fmt.Sprintf("f(1) = %g, expected 1", r.V1))
+	}
+	if !r.Finite {
+		errs = append(errs, "f is not finite over [0,1]")
+	}
+	if !r.Monotonic {
+		errs = append(errs, fmt.Sprintf("f decreases at t = %g", r.DecreaseT))
+	}
+	if !(r.MaxRoundTrip <= RoundTripTolerance) {
+		errs = append(errs, fmt.Sprintf("inverse round trip error %g at t = %g", r.MaxRoundTrip, r.MaxRoundTripT))
+	}
+	return errs
+}